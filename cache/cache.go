@@ -11,11 +11,16 @@ import (
 	"time"
 )
 
+// cacheEntry expires at ExpiresAt, or never when ExpiresAt is zero.
 type cacheEntry struct {
 	Data      json.RawMessage `json:"data"`
 	ExpiresAt time.Time       `json:"expires_at"`
 }
 
+func (e *cacheEntry) isExpired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && now.After(e.ExpiresAt)
+}
+
 type cache struct {
 	entries  map[string]*cacheEntry
 	mutex    sync.RWMutex
@@ -74,29 +79,54 @@ func (c *cache) get(gameId string, month string, year string) (json.RawMessage,
 		return nil, false
 	}
 
-	if time.Now().After(entry.ExpiresAt) {
-		delete(c.entries, key)
-		os.Remove(c.getCacheFilePath(key))
+	if entry.isExpired(time.Now()) {
 		return nil, false
 	}
 
 	return entry.Data, true
 }
 
+// GetStale returns the cached data even when it expired, for when it cannot be refreshed.
+// Expired entries are kept until they are replaced by Set or removed by ClearCache.
+func GetStale(gameId string, month string, year string) (json.RawMessage, time.Time, bool) {
+	cache := getCache()
+	if cache == nil {
+		return nil, time.Time{}, false
+	}
+
+	return cache.getStale(gameId, month, year)
+}
+
+func (c *cache) getStale(gameId string, month string, year string) (json.RawMessage, time.Time, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	entry, exists := c.entries[c.getCacheKey(gameId, month, year)]
+	if !exists {
+		return nil, time.Time{}, false
+	}
+
+	return entry.Data, entry.ExpiresAt, true
+}
+
 func Set(gameId string, month string, year string, data json.RawMessage, ttl time.Duration) {
-	getCache().set(gameId, month, year, data, ttl)
+	getCache().set(gameId, month, year, data, time.Now().Add(ttl))
+}
+
+// SetPermanent caches data that can no longer change, such as the results of a closed month.
+func SetPermanent(gameId string, month string, year string, data json.RawMessage) {
+	getCache().set(gameId, month, year, data, time.Time{})
 }
 
-func (c *cache) set(gameId string, month string, year string, data json.RawMessage, ttl time.Duration) {
+func (c *cache) set(gameId string, month string, year string, data json.RawMessage, expiresAt time.Time) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
 	key := c.getCacheKey(gameId, month, year)
-	now := time.Now()
 
 	entry := &cacheEntry{
 		Data:      data,
-		ExpiresAt: now.Add(ttl),
+		ExpiresAt: expiresAt,
 	}
 
 	c.entries[key] = entry
@@ -146,14 +176,27 @@ func (c *cache) loadEntryFromDisk(key string) {
 		return
 	}
 
-	if time.Now().After(entry.ExpiresAt) {
-		os.Remove(filePath)
-		return
-	}
-
 	c.entries[key] = &entry
 }
 
+// Delete removes the cached data of a month, e.g. after its draws were replaced in the store.
+func Delete(gameId string, month string, year string) {
+	getCache().delete(gameId, month, year)
+}
+
+func (c *cache) delete(gameId string, month string, year string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := c.getCacheKey(gameId, month, year)
+
+	delete(c.entries, key)
+
+	if err := os.Remove(c.getCacheFilePath(key)); err != nil && !os.IsNotExist(err) {
+		logError(err)
+	}
+}
+
 func ClearCache() {
 	getCache().clear()
 }
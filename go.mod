@@ -2,10 +2,15 @@ module loto-suite/backend
 
 go 1.25.2
 
-require github.com/PuerkitoBio/goquery v1.11.0
+require (
+	github.com/PuerkitoBio/goquery v1.11.0
+	github.com/google/uuid v1.6.0
+	go.etcd.io/bbolt v1.4.3
+	golang.org/x/sync v0.10.0
+)
 
 require (
 	github.com/andybalholm/cascadia v1.3.3 // indirect
-	github.com/google/uuid v1.6.0 // indirect
 	golang.org/x/net v0.47.0 // indirect
+	golang.org/x/sys v0.38.0 // indirect
 )
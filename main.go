@@ -1,16 +1,20 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"loto-suite/backend/generics"
 	"loto-suite/backend/logging"
 	"loto-suite/backend/models"
+	"loto-suite/backend/store"
+	"loto-suite/backend/tickets"
 	"loto-suite/backend/utils"
 	"net/http"
 	"os"
@@ -30,7 +34,44 @@ type contextKey string
 
 const traceIDKey contextKey = "traceID"
 
+// maxSavedTicketIds caps the saved tickets read by one GET /api/tickets?ids= request.
+const maxSavedTicketIds = 100
+
 func main() {
+	if gamesConfigFile := os.Getenv("GAMES_CONFIG_FILE"); gamesConfigFile != "" {
+		if err := utils.LoadGamesConfig(gamesConfigFile); err != nil {
+			log.Fatal(err)
+		}
+
+		log.Printf("Loaded game definitions from %s", gamesConfigFile)
+	}
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "export":
+			os.Exit(runExportDraws(os.Args[2:]))
+		case "import":
+			os.Exit(runImportDraws(os.Args[2:]))
+		}
+	}
+
+	if err := utils.LoadDrawSource(os.Getenv("DRAW_SOURCE"), os.Getenv("DRAW_SOURCE_PATH")); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Reading draw results from the %s source", utils.GetDrawSource().Name())
+
+	if len(os.Args) > 1 && os.Args[1] == "backfill" {
+		os.Exit(runBackfill(os.Args[2:]))
+	}
+
+	// The scheduler measures when loto.ro publishes the results, which only the scraper can tell
+	if utils.IsDrawSourceStored() {
+		utils.StartDrawScheduler(utils.GetEnvDuration("DRAW_POLL_INTERVAL", 5*time.Minute))
+	}
+
+	utils.StartTicketWatcher(utils.GetEnvDuration("TICKET_CHECK_INTERVAL", 10*time.Minute))
+
 	srv := NewServer()
 
 	port := os.Getenv("HTTPS_PORT")
@@ -55,7 +96,14 @@ func NewServer() *Server {
 	s.mux.HandleFunc("/api/games", corsMiddleware(s.handleGetGames))
 	s.mux.HandleFunc("/api/draw-dates", corsMiddleware(s.handleGetDrawDates))
 	s.mux.HandleFunc("/api/draw-results", corsMiddleware(s.handleGetDrawResults))
+	s.mux.HandleFunc("/api/draw-history", corsMiddleware(s.handleGetDrawHistory))
+	s.mux.HandleFunc("/api/draw-history/export", corsMiddleware(s.handleExportDrawHistory))
 	s.mux.HandleFunc("/api/check", corsMiddleware(s.handleVerificareBilet))
+	s.mux.HandleFunc("/api/check/batch", corsMiddleware(s.handleVerificareBilete))
+	s.mux.HandleFunc("/api/validate", corsMiddleware(s.handleValidareBilet))
+	s.mux.HandleFunc("/api/cost", corsMiddleware(s.handleCostBilet))
+	s.mux.HandleFunc("/api/backtest", corsMiddleware(s.handleBacktest))
+	s.mux.HandleFunc("/api/tickets", corsMiddleware(s.handleBileteSalvate))
 	s.mux.HandleFunc("/api/scan", corsMiddleware(s.handleScanareBilet))
 	s.mux.HandleFunc("/api/logs", corsMiddleware(s.handleDownloadLogs))
 	s.mux.HandleFunc("/api/health", corsMiddleware(s.handleHealthCheck))
@@ -126,23 +174,111 @@ func (s *Server) handleGetDrawResults(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	month := strconv.Itoa(int(queryDate.Month()))
-	year := strconv.Itoa(queryDate.Year())
+	result, err := utils.GetDrawResult(queryGameId, queryDate)
+	if errors.Is(err, utils.ErrDrawNotFound) {
+		respondWithJSON(w, r, models.DrawResult{})
+		return
+	}
 
-	drawResults, err := utils.GetDrawResults(queryGameId, month, year)
 	if err != nil {
 		respondWithError(w, r, err.Error(), http.StatusInternalServerError, "be")
 		return
 	}
 
-	result, _ := generics.FindFirst(drawResults, func(dr models.DrawResult) bool {
-		date, err := generics.TryParseDate(dr.GameDate)
-		return err == nil && date.Equal(queryDate)
-	})
-
 	respondWithJSON(w, r, result)
 }
 
+// handleGetDrawHistory returns the locally stored draws of a game, either between two dates
+// (?game=649&from=2024-01-01&to=2024-12-31) or the latest ones (?game=649&latest=10).
+func (s *Server) handleGetDrawHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	queryGameId := strings.TrimSpace(query.Get("game"))
+
+	if queryGameId == "" {
+		respondWithError(w, r, "missing game parameter", http.StatusBadRequest, "fe")
+		return
+	}
+
+	if latestStr := strings.TrimSpace(query.Get("latest")); latestStr != "" {
+		latest, err := strconv.Atoi(latestStr)
+		if err != nil {
+			respondWithError(w, r, "invalid latest parameter", http.StatusBadRequest, "fe")
+			return
+		}
+
+		drawResults, err := utils.GetLatestDrawResults(queryGameId, latest)
+		if err != nil {
+			respondWithHistoryError(w, r, err)
+			return
+		}
+
+		respondWithJSON(w, r, drawResults)
+		return
+	}
+
+	from, errFrom := generics.TryParseDate(query.Get("from"))
+	to, errTo := generics.TryParseDate(query.Get("to"))
+	if errFrom != nil || errTo != nil {
+		respondWithError(w, r, "missing or invalid from/to parameters", http.StatusBadRequest, "fe")
+		return
+	}
+
+	drawResults, err := utils.GetDrawHistory(queryGameId, from, to)
+	if err != nil {
+		respondWithHistoryError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, r, drawResults)
+}
+
+// handleExportDrawHistory downloads the stored draws of a game between two dates, with their prize
+// categories, as CSV or JSON (?game=649&from=2024-01-01&to=2024-12-31&format=csv).
+func (s *Server) handleExportDrawHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	queryGameId := strings.TrimSpace(query.Get("game"))
+
+	if queryGameId == "" {
+		respondWithError(w, r, "missing game parameter", http.StatusBadRequest, "fe")
+		return
+	}
+
+	from, errFrom := generics.TryParseDate(query.Get("from"))
+	to, errTo := generics.TryParseDate(query.Get("to"))
+	if errFrom != nil || errTo != nil {
+		respondWithError(w, r, "missing or invalid from/to parameters", http.StatusBadRequest, "fe")
+		return
+	}
+
+	format := query.Get("format")
+	if format == "" {
+		format = utils.DrawFormatJson
+	}
+
+	format, err := utils.GetDrawFormat(format, "")
+	if err != nil {
+		respondWithError(w, r, err.Error(), http.StatusBadRequest, "fe")
+		return
+	}
+
+	var data bytes.Buffer
+	if err := utils.ExportDrawHistory(&data, queryGameId, from, to, format); err != nil {
+		respondWithHistoryError(w, r, err)
+		return
+	}
+
+	contentType := "application/json"
+	if format == utils.DrawFormatCsv {
+		contentType = "text/csv"
+	}
+
+	fileName := fmt.Sprintf("%s_%s_%s.%s", strings.ToLower(queryGameId), from.Format(generics.GoDateFormat), to.Format(generics.GoDateFormat), format)
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	w.Write(data.Bytes())
+}
+
 func (s *Server) handleVerificareBilet(w http.ResponseWriter, r *http.Request) {
 	req := models.CheckRequest{}
 
@@ -153,15 +289,167 @@ func (s *Server) handleVerificareBilet(w http.ResponseWriter, r *http.Request) {
 
 	// logging.Info("BE", fmt.Sprintf("%s payload: %s", r.Method, generics.SerializeIgnoreError(req)))
 
+	withBreakdown := isBreakdownRequested(r)
+
+	if utils.GetDrawCount(req) > 1 {
+		result, err := utils.CheckTicketMultiDraw(req)
+		if err != nil {
+			respondWithCheckError(w, r, err)
+			return
+		}
+
+		if withBreakdown {
+			utils.AddMultiDrawWinBreakdown(result)
+		}
+
+		respondWithJSON(w, r, result)
+		return
+	}
+
 	result, err := utils.CheckTicket(req)
 	if err != nil {
-		respondWithError(w, r, err.Error(), http.StatusInternalServerError, "be")
+		respondWithCheckError(w, r, err)
+		return
+	}
+
+	if withBreakdown {
+		utils.AddWinBreakdown(result)
+	}
+
+	respondWithJSON(w, r, result)
+}
+
+func (s *Server) handleValidareBilet(w http.ResponseWriter, r *http.Request) {
+	req := models.CheckRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, r, "invalid request body", http.StatusBadRequest, "fe")
+		return
+	}
+
+	result := utils.ValidateTicket(req)
+	respondWithJSON(w, r, result)
+}
+
+func (s *Server) handleCostBilet(w http.ResponseWriter, r *http.Request) {
+	req := models.CheckRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, r, "invalid request body", http.StatusBadRequest, "fe")
+		return
+	}
+
+	result, err := utils.CalculateTicketCost(req)
+	if err != nil {
+		respondWithCheckError(w, r, err)
+		return
+	}
+
+	respondWithJSON(w, r, result)
+}
+
+func (s *Server) handleVerificareBilete(w http.ResponseWriter, r *http.Request) {
+	reqs := []models.CheckRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+		respondWithError(w, r, "invalid request body", http.StatusBadRequest, "fe")
+		return
+	}
+
+	if len(reqs) == 0 {
+		respondWithError(w, r, "at least one ticket is required", http.StatusBadRequest, "fe")
+		return
+	}
+
+	if len(reqs) > utils.MaxBatchSize {
+		respondWithError(w, r, fmt.Sprintf("at most %d tickets can be checked at once", utils.MaxBatchSize), http.StatusBadRequest, "fe")
+		return
+	}
+
+	results := utils.CheckTickets(reqs)
+
+	if isBreakdownRequested(r) {
+		for _, result := range results {
+			utils.AddWinBreakdown(result.Result)
+			utils.AddMultiDrawWinBreakdown(result.MultiDrawResult)
+		}
+	}
+
+	respondWithJSON(w, r, results)
+}
+
+func (s *Server) handleBacktest(w http.ResponseWriter, r *http.Request) {
+	req := models.BacktestRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, r, "invalid request body", http.StatusBadRequest, "fe")
+		return
+	}
+
+	result, err := utils.Backtest(req)
+	if err != nil {
+		respondWithCheckError(w, r, err)
 		return
 	}
 
 	respondWithJSON(w, r, result)
 }
 
+// handleBileteSalvate registers a ticket for an upcoming draw (POST) or returns saved tickets:
+// one looked up by its id (GET ?id=) or a list of them (GET ?ids=a,b,c), optionally filtered
+// by ?stare=pending|checked|failed.
+func (s *Server) handleBileteSalvate(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		req := models.CheckRequest{}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondWithError(w, r, "invalid request body", http.StatusBadRequest, "fe")
+			return
+		}
+
+		result, err := utils.SaveTicket(req)
+		if err != nil {
+			respondWithCheckError(w, r, err)
+			return
+		}
+
+		respondWithJSON(w, r, result)
+		return
+	}
+
+	// Tickets are not tied to accounts, so the ids returned on registration are the only way to read them
+	if idsStr := strings.TrimSpace(r.URL.Query().Get("ids")); idsStr != "" {
+		ids := []string{}
+		for _, id := range strings.Split(idsStr, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+
+		if len(ids) > maxSavedTicketIds {
+			respondWithError(w, r, fmt.Sprintf("at most %d ids can be requested at once", maxSavedTicketIds), http.StatusBadRequest, "fe")
+			return
+		}
+
+		respondWithJSON(w, r, tickets.GetMany(ids, strings.TrimSpace(r.URL.Query().Get("stare"))))
+		return
+	}
+
+	id := strings.TrimSpace(r.URL.Query().Get("id"))
+	if id == "" {
+		respondWithError(w, r, "missing id or ids parameter", http.StatusBadRequest, "fe")
+		return
+	}
+
+	ticket, found := tickets.Get(id)
+	if !found {
+		respondWithError(w, r, "ticket not found", http.StatusNotFound, "fe")
+		return
+	}
+
+	respondWithJSON(w, r, ticket)
+}
+
 func (s *Server) handleScanareBilet(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		GameId    string `json:"game_id"`
@@ -227,10 +515,193 @@ func (s *Server) handleDownloadLogs(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleHealthCheck answers "OK" while the server is up; with ?details=1 it reports the
+// months whose scraped results currently fail the layout checks.
 func (s *Server) handleHealthCheck(w http.ResponseWriter, r *http.Request) {
+	if details, _ := strconv.ParseBool(r.URL.Query().Get("details")); details {
+		respondWithJSON(w, r, utils.GetHealthStatus())
+		return
+	}
+
 	w.Write([]byte("OK"))
 }
 
+// runBackfill stores the full history of draw results locally:
+//
+//	backend backfill [-games 649,joker] [-delay 2s] [-retry-failed]
+func runBackfill(args []string) int {
+	flags := flag.NewFlagSet("backfill", flag.ExitOnError)
+	gameIds := flags.String("games", "", "comma separated game ids (default: all games)")
+	delay := flags.Duration("delay", 2*time.Second, "minimum time between two requests to loto.ro")
+	retryFailed := flags.Bool("retry-failed", false, "only retry the months that failed in previous runs")
+	flags.Parse(args)
+
+	options := utils.BackfillOptions{
+		Delay:           *delay,
+		RetryFailedOnly: *retryFailed,
+		Progress: func(month models.BackfillMonth) {
+			line := fmt.Sprintf("%s %02d/%d: %s (%d draws)", month.GameId, month.Month, month.Year, month.State, month.DrawsCount)
+			if month.Error != "" {
+				line += ": " + month.Error
+			}
+
+			log.Println(line)
+		},
+	}
+
+	if *gameIds != "" {
+		options.GameIds = strings.Split(*gameIds, ",")
+	}
+
+	report, err := utils.Backfill(options)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+
+	log.Printf("Backfill done: %d months stored, %d empty, %d already stored or skipped, %d failed",
+		report.Stored, report.Empty, report.Skipped, len(report.Failed))
+
+	for _, month := range report.Failed {
+		log.Printf("failed: %s %02d/%d after %d attempt(s): %s", month.GameId, month.Month, month.Year, month.Attempts, month.Error)
+	}
+
+	if len(report.Failed) > 0 {
+		log.Println("Run again with -retry-failed to retry the failed months")
+		return 1
+	}
+
+	return 0
+}
+
+// runExportDraws writes the stored draws of a game between two dates to a CSV or JSON file, or to stdout.
+func runExportDraws(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	gameId := flags.String("game", "", "game id")
+	fromStr := flags.String("from", "", "first draw date, e.g. 2024-01-01")
+	toStr := flags.String("to", time.Now().Format(generics.GoDateFormat), "last draw date")
+	format := flags.String("format", "", "csv or json (default: the extension of -out, else json)")
+	out := flags.String("out", "", "output file (default: stdout)")
+	flags.Parse(args)
+
+	from, errFrom := generics.TryParseDate(*fromStr)
+	to, errTo := generics.TryParseDate(*toStr)
+	if *gameId == "" || errFrom != nil || errTo != nil {
+		log.Println("-game, -from and -to are required, dates as 2024-01-01")
+		return 1
+	}
+
+	if *format == "" && *out == "" {
+		*format = utils.DrawFormatJson
+	}
+
+	drawFormat, err := utils.GetDrawFormat(*format, *out)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+
+	writer := os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			log.Println(err)
+			return 1
+		}
+
+		defer file.Close()
+		writer = file
+	}
+
+	if err := utils.ExportDrawHistory(writer, *gameId, from, to, drawFormat); err != nil {
+		log.Println(err)
+		return 1
+	}
+
+	return 0
+}
+
+// runImportDraws adds the draws of a CSV or JSON file to the local store, after validating all of them.
+// Importing is only possible from the command line, with the server stopped as it holds the store open.
+func runImportDraws(args []string) int {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	format := flags.String("format", "", "csv or json (default: the extension of the file)")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		log.Println("usage: import [-format csv|json] <file>")
+		return 1
+	}
+
+	drawFormat, err := utils.GetDrawFormat(*format, flags.Arg(0))
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+
+	defer file.Close()
+
+	report, err := utils.ImportDrawResults(file, drawFormat)
+	if err != nil {
+		var importErr *utils.DrawImportValidationError
+		if !errors.As(err, &importErr) {
+			log.Println(err)
+			return 1
+		}
+
+		for _, importError := range importErr.Errors {
+			log.Printf("draw %d (%s %s): %s", importError.Index, importError.GameId, importError.DrawDate, strings.Join(importError.Problems, "; "))
+		}
+
+		log.Printf("Nothing imported: %d invalid draw(s)", len(importErr.Errors))
+		return 1
+	}
+
+	for gameId, count := range report.Games {
+		log.Printf("%s: %d draws", gameId, count)
+	}
+
+	log.Printf("Imported %d draws", report.Imported)
+
+	return 0
+}
+
+// isBreakdownRequested reports whether the client asked for the per-variant win breakdown (?detalii=true).
+func isBreakdownRequested(r *http.Request) bool {
+	withBreakdown, _ := strconv.ParseBool(r.URL.Query().Get("detalii"))
+	return withBreakdown
+}
+
+func respondWithCheckError(w http.ResponseWriter, r *http.Request, err error) {
+	var validationErr *utils.TicketValidationError
+	if errors.As(err, &validationErr) {
+		respondWithValidationErrors(w, r, validationErr.Errors)
+		return
+	}
+
+	if errors.Is(err, utils.ErrUnsupportedGame) {
+		respondWithError(w, r, err.Error(), http.StatusBadRequest, "fe")
+	} else if errors.Is(err, utils.ErrCircuitOpen) {
+		respondWithError(w, r, err.Error(), http.StatusServiceUnavailable, "be")
+	} else {
+		respondWithError(w, r, err.Error(), http.StatusInternalServerError, "be")
+	}
+}
+
+func respondWithHistoryError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, store.ErrStoreUnavailable) {
+		respondWithError(w, r, err.Error(), http.StatusInternalServerError, "be")
+	} else {
+		respondWithError(w, r, err.Error(), http.StatusBadRequest, "fe")
+	}
+}
+
 func respondWithJSON(w http.ResponseWriter, r *http.Request, data any) {
 	traceID, _ := r.Context().Value(traceIDKey).(string)
 	logging.Info("be", fmt.Sprintf("[TraceID: %s] Success response", traceID))
@@ -247,6 +718,21 @@ func respondWithJSON(w http.ResponseWriter, r *http.Request, data any) {
 	encoder.Encode(response)
 }
 
+func respondWithValidationErrors(w http.ResponseWriter, r *http.Request, validationErrors []models.ValidationError) {
+	traceID, _ := r.Context().Value(traceIDKey).(string)
+	logMsg := fmt.Sprintf("[TraceID: %s] invalid ticket: %s", traceID, generics.SerializeIgnoreError(validationErrors))
+
+	logging.Error("fe", errors.New(logMsg), "")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]any{
+		"error":    "invalid ticket",
+		"errors":   validationErrors,
+		"trace_id": traceID,
+	})
+}
+
 func respondWithError(w http.ResponseWriter, r *http.Request, message string, status int, source string) {
 	traceID, _ := r.Context().Value(traceIDKey).(string)
 	logMsg := fmt.Sprintf("[TraceID: %s] %s", traceID, message)
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+const (
+	BackfillMonthStored = "stored"
+	BackfillMonthEmpty  = "empty"
+	BackfillMonthFailed = "failed"
+)
+
+// BackfillMonth is the outcome of the last backfill attempt for one game and month.
+type BackfillMonth struct {
+	GameId      string    `json:"game_id"`
+	Month       int       `json:"luna"`
+	Year        int       `json:"an"`
+	State       string    `json:"stare"`
+	DrawsCount  int       `json:"numar_extrageri"`
+	Attempts    int       `json:"incercari"`
+	Error       string    `json:"error,omitempty"`
+	AttemptedAt time.Time `json:"incercat_la"`
+}
+
+type BackfillReport struct {
+	Stored  int             `json:"stocate"`
+	Skipped int             `json:"sarite"`
+	Empty   int             `json:"goale"`
+	Failed  []BackfillMonth `json:"esuate"`
+}
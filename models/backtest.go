@@ -0,0 +1,41 @@
+package models
+
+type BacktestRequest struct {
+	GameId       string    `json:"game_id"`
+	LuckyNumber  string    `json:"noroc,omitempty"`
+	DateFrom     string    `json:"date_from"`
+	DateTo       string    `json:"date_to,omitempty"`
+	Variants     []Variant `json:"variante"`
+	SpecialDraw  bool      `json:"extragere_speciala,omitempty"`
+	StakePerDraw float64   `json:"miza_per_extragere,omitempty"`
+}
+
+type BacktestResult struct {
+	GameId       string         `json:"game_id"`
+	DateFrom     string         `json:"date_from"`
+	DateTo       string         `json:"date_to"`
+	DrawsChecked int            `json:"extrageri_verificate"`
+	DrawsWon     int            `json:"extrageri_castigatoare"`
+	WinningDraws []BacktestDraw `json:"castiguri_extrageri"`
+	WinsTotal    float64        `json:"castiguri_total"`
+	TaxTotal     float64        `json:"impozit_total"`
+	WinsTotalNet float64        `json:"castiguri_total_net"`
+	StakePerDraw float64        `json:"miza_per_extragere"`
+	StakeTotal   float64        `json:"miza_totala"`
+	NetResult    float64        `json:"rezultat_net"`
+	ReturnRate   float64        `json:"rata_returnare"`
+	// Partial is set when months of the range were neither in the local store nor fetched,
+	// and lists them in MonthsSkipped as YYYY-MM
+	Partial       bool     `json:"partial"`
+	MonthsSkipped []string `json:"luni_omise,omitempty"`
+}
+
+type BacktestDraw struct {
+	Date                        string         `json:"date"`
+	WinsCumulatedVariantRegular []WinCumulated `json:"castiguri_varianta,omitempty"`
+	WinsCumulatedVariantSpecial []WinCumulated `json:"castiguri_varianta_speciala,omitempty"`
+	WinsCumulatedLuckyNumber    []WinCumulated `json:"castiguri_noroc,omitempty"`
+	WinsTotal                   float64        `json:"castiguri_total"`
+	TaxTotal                    float64        `json:"impozit_total"`
+	WinsTotalNet                float64        `json:"castiguri_total_net"`
+}
@@ -4,6 +4,9 @@ type CheckRequest struct {
 	GameId      string    `json:"game_id"`
 	LuckyNumber string    `json:"noroc,omitempty"`
 	Date        string    `json:"date"`
+	DrawCount   int       `json:"numar_extrageri,omitempty"`
+	DateTo      string    `json:"date_to,omitempty"`
+	SpecialDraw bool      `json:"extragere_speciala,omitempty"`
 	Variants    []Variant `json:"variante"`
 }
 
@@ -16,4 +19,32 @@ type CheckResult struct {
 	WinsCumulatedVariantSpecial []WinCumulated `json:"castiguri_varianta_speciala,omitempty"`
 	WinsCumulatedLuckyNumber    []WinCumulated `json:"castiguri_noroc,omitempty"`
 	WinsTotal                   float64        `json:"castiguri_total"`
+	TaxTotal                    float64        `json:"impozit_total"`
+	WinsTotalNet                float64        `json:"castiguri_total_net"`
+	WinsPendingCount            int            `json:"castiguri_in_asteptare,omitempty"`
+}
+
+type MultiDrawCheckResult struct {
+	IsCastigator     bool              `json:"is_castigator"`
+	Draws            []DrawCheckResult `json:"extrageri"`
+	DrawsChecked     int               `json:"extrageri_verificate"`
+	DrawsPending     int               `json:"extrageri_in_asteptare"`
+	WinsTotal        float64           `json:"castiguri_total"`
+	TaxTotal         float64           `json:"impozit_total"`
+	WinsTotalNet     float64           `json:"castiguri_total_net"`
+	WinsPendingCount int               `json:"castiguri_in_asteptare,omitempty"`
+}
+
+type DrawCheckResult struct {
+	Date   string       `json:"date"`
+	Result *CheckResult `json:"rezultat,omitempty"`
+	Error  string       `json:"error,omitempty"`
+}
+
+type BatchCheckItem struct {
+	Index            int                   `json:"index"`
+	Result           *CheckResult          `json:"rezultat,omitempty"`
+	MultiDrawResult  *MultiDrawCheckResult `json:"rezultat_extrageri,omitempty"`
+	Error            string                `json:"error,omitempty"`
+	ValidationErrors []ValidationError     `json:"validation_errors,omitempty"`
 }
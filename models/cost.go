@@ -0,0 +1,22 @@
+package models
+
+// GamePrice holds the operator list prices of a game. A zero SpecialDrawVariantPrice means
+// that taking part in the special draw is included in the variant price.
+type GamePrice struct {
+	Currency                string  `json:"moneda"`
+	VariantPrice            float64 `json:"pret_varianta"`
+	LuckyNumberPrice        float64 `json:"pret_noroc"`
+	SpecialDrawVariantPrice float64 `json:"pret_varianta_extragere_speciala"`
+}
+
+type TicketCost struct {
+	GameId          string  `json:"game_id"`
+	Currency        string  `json:"moneda"`
+	Lines           int     `json:"numar_linii"`
+	Draws           int     `json:"numar_extrageri"`
+	VariantsCost    float64 `json:"cost_variante"`
+	LuckyNumberCost float64 `json:"cost_noroc"`
+	SpecialDrawCost float64 `json:"cost_extragere_speciala"`
+	CostPerDraw     float64 `json:"cost_per_extragere"`
+	Total           float64 `json:"cost_total"`
+}
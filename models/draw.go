@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type DrawDate struct {
 	Date  string `json:"date"`
 	Label string `json:"label"`
@@ -12,10 +14,32 @@ type DrawResult struct {
 	VariantSpecial              *Variant      `json:"varianta_speciala,omitempty"`
 	LuckyNumber                 *LuckyNumber  `json:"noroc"`
 	LuckyNumberName             string        `json:"nume_noroc"`
-	WinCategoriesVariantRegular []WinCategory `json:"-"`
-	WinCategoriesVariantSpecial []WinCategory `json:"-"`
-	WinCategoriesLuckyNumber    []WinCategory `json:"-"`
-	// WinCategoriesVariantRegular []WinCategory `json:"categorii_castig_varianta,omitempty"`
-	// WinCategoriesVariantSpecial []WinCategory `json:"categorii_castig_varianta_speciala,omitempty"`
-	// WinCategoriesLuckyNumber    []WinCategory `json:"categorii_castig_noroc,omitempty"`
+	WinCategoriesVariantRegular []WinCategory `json:"categorii_castig_varianta,omitempty"`
+	WinCategoriesVariantSpecial []WinCategory `json:"categorii_castig_varianta_speciala,omitempty"`
+	WinCategoriesLuckyNumber    []WinCategory `json:"categorii_castig_noroc,omitempty"`
+	JackpotVariantRegular       float64       `json:"jackpot_varianta,omitempty"`
+	JackpotVariantSpecial       float64       `json:"jackpot_varianta_speciala,omitempty"`
+	JackpotLuckyNumber          float64       `json:"jackpot_noroc,omitempty"`
+}
+
+// DrawAvailability records when the results of a draw were first seen on loto.ro.
+type DrawAvailability struct {
+	GameId      string    `json:"game_id"`
+	DrawDate    string    `json:"date"`
+	DrawAt      time.Time `json:"extragere_la"`
+	AvailableAt time.Time `json:"disponibil_la"`
+	Attempts    int       `json:"incercari"`
+}
+
+// DrawImportError lists why an imported draw was rejected. Index is its position in the file, from 1.
+type DrawImportError struct {
+	Index    int      `json:"index"`
+	GameId   string   `json:"game_id"`
+	DrawDate string   `json:"date"`
+	Problems []string `json:"probleme"`
+}
+
+type DrawImportReport struct {
+	Imported int            `json:"importate"`
+	Games    map[string]int `json:"jocuri"`
 }
@@ -1,15 +1,59 @@
 package models
 
 type Game struct {
-	Id                      string `json:"id"`
-	DisplayName             string `json:"display_name"`
-	Url                     string `json:"url"`
-	LuckyNumberDigitCount   int    `json:"numar_cifre_noroc"`
-	LuckyNumberMinMatchLen  int    `json:"noroc_min_match_len"`
-	VariantMinNumbersCount  int    `json:"min_numere_per_varianta_jucata"`
-	VariantsMaxCount        int    `json:"numar_max_variante"`
-	VariantDrawNumbersCount int    `json:"numere_per_varianta_extrasa"`
-	VariantMinNumber        int    `json:"min_value_numar_varianta"`
-	VariantMaxNumber        int    `json:"max_value_numar_varianta"`
-	LuckyNumberName         string `json:"nume_noroc"`
+	Id                       string                    `json:"id"`
+	DisplayName              string                    `json:"display_name"`
+	Url                      string                    `json:"url"`
+	FirstDrawDate            string                    `json:"prima_extragere,omitempty"`
+	LuckyNumberDigitCount    int                       `json:"numar_cifre_noroc"`
+	VariantMinNumbersCount   int                       `json:"min_numere_per_varianta_jucata"`
+	VariantMaxNumbersCount   int                       `json:"max_numere_per_varianta_jucata"`
+	VariantsMaxCount         int                       `json:"numar_max_variante"`
+	ConsecutiveDrawsMaxCount int                       `json:"numar_max_extrageri_consecutive"`
+	VariantDrawNumbersCount  int                       `json:"numere_per_varianta_extrasa"`
+	VariantMinNumber         int                       `json:"min_value_numar_varianta"`
+	VariantMaxNumber         int                       `json:"max_value_numar_varianta"`
+	SecondaryPool            *NumberPool               `json:"pool_secundar,omitempty"`
+	LuckyNumberName          string                    `json:"nume_noroc"`
+	VariantCategories        []VariantCategoryRule     `json:"categorii_castig_varianta"`
+	LuckyNumberCategories    []LuckyNumberCategoryRule `json:"categorii_castig_noroc"`
+	Price                    GamePrice                 `json:"pret"`
+}
+
+// NumberPool describes numbers drawn from their own range, separately from the main numbers
+// of a variant (e.g. the JOKER ball, drawn from 1-20 after the first set of 5 from 1-45).
+type NumberPool struct {
+	Name      string `json:"nume"`
+	Count     int    `json:"numar_numere"`
+	MinNumber int    `json:"min_value"`
+	MaxNumber int    `json:"max_value"`
+}
+
+// VariantCategoryRule describes a prize category of a variant. Categories are listed from the
+// highest to the lowest and a played line wins only the first one it matches.
+type VariantCategoryRule struct {
+	Id               string  `json:"id"`
+	Description      string  `json:"descriere"`
+	Matches          int     `json:"numere_potrivite"`
+	OrMore           bool    `json:"sau_mai_multe,omitempty"`
+	FromFirst        int     `json:"din_primele,omitempty"`
+	SecondaryMatches int     `json:"numere_secundare_potrivite,omitempty"`
+	FixedAmount      float64 `json:"suma_fixa,omitempty"`
+}
+
+const (
+	LuckyNumberMatchLast        = "last"
+	LuckyNumberMatchFirstOrLast = "first_or_last"
+)
+
+// LuckyNumberCategoryRule describes a prize category of the lucky number: either Digits
+// consecutive digits matched at the end (or start) of the number, or the whole number
+// shifted by Offset. Among the digit categories only the first one matched is won.
+type LuckyNumberCategoryRule struct {
+	Id          string  `json:"id"`
+	Description string  `json:"descriere"`
+	Digits      int     `json:"cifre,omitempty"`
+	Match       string  `json:"potrivire,omitempty"`
+	Offset      int     `json:"diferenta,omitempty"`
+	FixedAmount float64 `json:"suma_fixa,omitempty"`
 }
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+const (
+	HealthStatusOk       = "ok"
+	HealthStatusDegraded = "degraded"
+)
+
+// ScrapeFailure is the last failed layout check of the results page of a game and month.
+type ScrapeFailure struct {
+	GameId     string    `json:"game_id"`
+	Month      string    `json:"luna"`
+	Year       string    `json:"an"`
+	Problems   []string  `json:"probleme"`
+	Count      int       `json:"numar_esecuri"`
+	LastFailAt time.Time `json:"ultimul_esec"`
+}
+
+type HealthStatus struct {
+	Status          string                `json:"status"`
+	ScrapeFailures  []ScrapeFailure       `json:"scrape_failures"`
+	CircuitBreakers []CircuitBreakerState `json:"circuit_breakers"`
+	LatestDraws     []DrawAvailability    `json:"latest_draws"`
+}
@@ -1,17 +1,25 @@
 package models
 
 type LuckyNumber struct {
-	Value    string `json:"numar"`
-	IsWinner bool   `json:"castigator,omitempty"`
-	Wins     []Win  `json:"-"`
+	Value     string        `json:"numar"`
+	IsWinner  bool          `json:"castigator,omitempty"`
+	Breakdown *WinBreakdown `json:"detalii,omitempty"`
+	Wins      []Win         `json:"-"`
 	// Wins     []Win  `json:"castiguri,omitempty"`
 }
 
 type Variant struct {
-	Id          int      `json:"id"`
-	Numbers     []Number `json:"numere,omitempty"`
-	WinsRegular []Win    `json:"-"`
-	WinsSpecial []Win    `json:"-"`
+	Id                   int            `json:"id"`
+	Numbers              []Number       `json:"numere,omitempty"`
+	SecondaryNumbers     []Number       `json:"numere_secundare,omitempty"`
+	IsSystematic         bool           `json:"sistem,omitempty"`
+	Combinations         []Variant      `json:"combinatii,omitempty"`
+	WinsCumulatedRegular []WinCumulated `json:"castiguri_varianta,omitempty"`
+	WinsCumulatedSpecial []WinCumulated `json:"castiguri_varianta_speciala,omitempty"`
+	WinsTotal            float64        `json:"castiguri_total,omitempty"`
+	Breakdown            *WinBreakdown  `json:"detalii,omitempty"`
+	WinsRegular          []Win          `json:"-"`
+	WinsSpecial          []Win          `json:"-"`
 	// WinsRegular []Win    `json:"castiguri,omitempty"`
 	// WinsSpecial []Win    `json:"castiguri_varianta_speciala,omitempty"`
 }
@@ -0,0 +1,34 @@
+package models
+
+type TaxBracket struct {
+	UpTo float64 `json:"pana_la"`
+	Rate float64 `json:"cota"`
+}
+
+type TaxBracketTable struct {
+	EffectiveFrom string       `json:"in_vigoare_de_la"`
+	Brackets      []TaxBracket `json:"transe"`
+}
+
+// TaxBracketTables holds the progressive tax on gambling winnings (Codul fiscal, art. 110), one table
+// per change of the law, ordered by EffectiveFrom. Each bracket taxes the part of a prize up to UpTo (0 = no limit).
+var TaxBracketTables = []*TaxBracketTable{
+	// Legea 227/2015 privind Codul fiscal, art. 110 alin. (2), in its original form
+	{
+		EffectiveFrom: "2016-01-01",
+		Brackets: []TaxBracket{
+			{UpTo: 66750, Rate: 0.01},
+			{UpTo: 445000, Rate: 0.16},
+			{UpTo: 0, Rate: 0.25},
+		},
+	},
+	// OUG 16/2022, art. I, amending art. 110 alin. (2) of the Codul fiscal for winnings from 2023-01-01
+	{
+		EffectiveFrom: "2023-01-01",
+		Brackets: []TaxBracket{
+			{UpTo: 10000, Rate: 0.03},
+			{UpTo: 66750, Rate: 0.20},
+			{UpTo: 0, Rate: 0.40},
+		},
+	},
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+const (
+	SavedTicketStatePending = "pending"
+	SavedTicketStateChecked = "checked"
+	// SavedTicketStateFailed is a ticket that can never be checked, e.g. its game is no longer supported
+	// or the results of its draw were not published in time.
+	SavedTicketStateFailed = "failed"
+)
+
+// SavedTicket is a ticket registered for an upcoming draw. It stays pending until the
+// results of all its draws are published and is then checked automatically.
+type SavedTicket struct {
+	Id              string                `json:"id"`
+	Request         CheckRequest          `json:"bilet"`
+	State           string                `json:"stare"`
+	CreatedAt       time.Time             `json:"creat_la"`
+	NextDrawAt      time.Time             `json:"urmatoarea_extragere"`
+	LastAttemptAt   *time.Time            `json:"ultima_incercare,omitempty"`
+	CheckedAt       *time.Time            `json:"verificat_la,omitempty"`
+	Result          *CheckResult          `json:"rezultat,omitempty"`
+	MultiDrawResult *MultiDrawCheckResult `json:"rezultat_extrageri,omitempty"`
+	Error           string                `json:"error,omitempty"`
+}
@@ -0,0 +1,33 @@
+package models
+
+const (
+	ValidationCodeGameRequired          = "game_required"
+	ValidationCodeUnsupportedGame       = "unsupported_game"
+	ValidationCodeDateRequired          = "date_required"
+	ValidationCodeInvalidDate           = "invalid_date"
+	ValidationCodeVariantsRequired      = "variants_required"
+	ValidationCodeTooManyVariants       = "too_many_variants"
+	ValidationCodeTooFewNumbers         = "too_few_numbers"
+	ValidationCodeTooManyNumbers        = "too_many_numbers"
+	ValidationCodeNumberOutOfRange      = "number_out_of_range"
+	ValidationCodeDuplicateNumber       = "duplicate_number"
+	ValidationCodeLuckyNumberLength     = "lucky_number_invalid_length"
+	ValidationCodeLuckyNumberNotNumeric = "lucky_number_not_numeric"
+	ValidationCodeDrawCountOutOfRange   = "draw_count_out_of_range"
+	ValidationCodeSecondaryNumbersCount = "invalid_secondary_numbers_count"
+	ValidationCodeNotADrawDay           = "not_a_draw_day"
+	ValidationCodeDrawAlreadyHeld       = "draw_already_held"
+)
+
+type ValidationError struct {
+	Field        string `json:"field"`
+	Code         string `json:"code"`
+	Message      string `json:"message"`
+	VariantIndex *int   `json:"varianta_index,omitempty"`
+	NumberIndex  *int   `json:"numar_index,omitempty"`
+}
+
+type ValidationResult struct {
+	IsValid bool              `json:"is_valid"`
+	Errors  []ValidationError `json:"errors"`
+}
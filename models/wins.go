@@ -6,14 +6,51 @@ type Win struct {
 	IsWinner    bool   `json:"castigator,omitempty"`
 }
 
+// PrizeState tells how the amount of a prize category is known.
+type PrizeState string
+
+const (
+	// PrizeStatePaid is an amount published with the draw results.
+	PrizeStatePaid PrizeState = "paid"
+	// PrizeStatePending is a category whose amount is not published yet.
+	PrizeStatePending PrizeState = "pending"
+	// PrizeStateRolledOver is a category nobody won in the draw; its pool went to the report.
+	PrizeStateRolledOver PrizeState = "rolled_over"
+	// PrizeStateFixed is an amount set by the game rules rather than by the draw.
+	PrizeStateFixed PrizeState = "fixed"
+)
+
 type WinCumulated struct {
-	Id          string  `json:"id"`
-	Description string  `json:"descriere"`
-	WinCount    int     `json:"win_count"`
-	Amount      float64 `json:"suma"`
+	Id          string     `json:"id"`
+	Description string     `json:"descriere"`
+	WinCount    int        `json:"win_count"`
+	Amount      float64    `json:"suma"`
+	State       PrizeState `json:"stare"`
+	Tax         float64    `json:"impozit"`
+	NetAmount   float64    `json:"suma_neta"`
 }
 
 type WinCategory struct {
-	Id     string  `json:"id_categorie"`
-	Amount float64 `json:"suma"`
+	Id           string     `json:"id_categorie"`
+	WinnersCount int        `json:"numar_castigatori"`
+	Amount       float64    `json:"suma"`
+	Report       float64    `json:"report"`
+	State        PrizeState `json:"stare,omitempty"`
+}
+
+type WinDetail struct {
+	Id          string     `json:"id"`
+	Description string     `json:"descriere"`
+	Amount      float64    `json:"suma"`
+	State       PrizeState `json:"stare"`
+}
+
+type WinBreakdown struct {
+	WinsRegular             []WinDetail `json:"castiguri_varianta,omitempty"`
+	WinsSpecial             []WinDetail `json:"castiguri_varianta_speciala,omitempty"`
+	WinsLuckyNumber         []WinDetail `json:"castiguri_noroc,omitempty"`
+	MatchedNumbersRegular   []int       `json:"numere_potrivite_varianta,omitempty"`
+	MatchedNumbersSpecial   []int       `json:"numere_potrivite_varianta_speciala,omitempty"`
+	MatchedSecondaryRegular []int       `json:"numere_secundare_potrivite_varianta,omitempty"`
+	MatchedSecondarySpecial []int       `json:"numere_secundare_potrivite_varianta_speciala,omitempty"`
 }
@@ -0,0 +1,366 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"loto-suite/backend/generics"
+	"loto-suite/backend/logging"
+	"loto-suite/backend/models"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// The database holds one bucket per game in drawsBucket, with the draws keyed by their
+// date (YYYY-MM-DD), so that keys sort chronologically and ranges are cursor seeks.
+// monthsBucket marks the months whose draws are complete (game/YYYY-MM).
+var (
+	drawsBucket  = []byte("draws")
+	monthsBucket = []byte("months")
+	metaBucket   = []byte("meta")
+
+	availabilityBucket = []byte("availability")
+
+	backfillProgressKey = []byte("backfill-progress")
+)
+
+var ErrStoreUnavailable = errors.New("the draw results store is not available")
+
+var (
+	db     *bolt.DB
+	dbErr  error
+	dbOnce sync.Once
+)
+
+// dataDir is the directory of the database, store/data next to this file unless set before first use.
+var dataDir string
+
+// getDB opens the database on first use. bbolt locks the file, so only one process
+// (the server or the backfill command) can use the store at a time.
+func getDB() (*bolt.DB, error) {
+	dbOnce.Do(func() {
+		if dataDir == "" {
+			_, filename, _, ok := runtime.Caller(0)
+			if !ok {
+				dbErr = ErrStoreUnavailable
+				return
+			}
+
+			dataDir = filepath.Join(filepath.Dir(filename), "data")
+		}
+
+		if dbErr = os.MkdirAll(dataDir, 0755); dbErr != nil {
+			return
+		}
+
+		db, dbErr = bolt.Open(filepath.Join(dataDir, "draws.db"), 0644, &bolt.Options{Timeout: 2 * time.Second})
+		if dbErr != nil {
+			dbErr = fmt.Errorf("%w: %v", ErrStoreUnavailable, dbErr)
+			logError(dbErr)
+			return
+		}
+
+		dbErr = db.Update(func(tx *bolt.Tx) error {
+			for _, bucket := range [][]byte{drawsBucket, monthsBucket, metaBucket, availabilityBucket} {
+				if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	})
+
+	return db, dbErr
+}
+
+func Close() error {
+	if db == nil {
+		return nil
+	}
+
+	return db.Close()
+}
+
+func getDateKey(date time.Time) []byte {
+	return []byte(date.Format(generics.GoDateFormat))
+}
+
+func getMonthKey(gameId string, month int, year int) []byte {
+	return []byte(fmt.Sprintf("%s/%04d-%02d", gameId, year, month))
+}
+
+func getMonthRange(month int, year int) (time.Time, time.Time) {
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	return from, from.AddDate(0, 1, -1)
+}
+
+// SaveDraws adds or replaces the given draws of a game.
+func SaveDraws(gameId string, drawResults []models.DrawResult) error {
+	db, err := getDB()
+	if err != nil {
+		return err
+	}
+
+	return db.Update(func(tx *bolt.Tx) error {
+		return putDraws(tx, gameId, drawResults)
+	})
+}
+
+func putDraws(tx *bolt.Tx, gameId string, drawResults []models.DrawResult) error {
+	games, err := tx.Bucket(drawsBucket).CreateBucketIfNotExists([]byte(gameId))
+	if err != nil {
+		return err
+	}
+
+	for _, drawResult := range drawResults {
+		drawDate, err := generics.TryParseDate(drawResult.GameDate)
+		if err != nil {
+			return fmt.Errorf("draw of %s: %w", gameId, err)
+		}
+
+		data, err := json.Marshal(drawResult)
+		if err != nil {
+			return err
+		}
+
+		if err := games.Put(getDateKey(drawDate), data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SaveMonth replaces the draws of a month and marks it as complete, even when it has no draws.
+func SaveMonth(gameId string, month int, year int, drawResults []models.DrawResult) error {
+	db, err := getDB()
+	if err != nil {
+		return err
+	}
+
+	from, to := getMonthRange(month, year)
+
+	return db.Update(func(tx *bolt.Tx) error {
+		if games := tx.Bucket(drawsBucket).Bucket([]byte(gameId)); games != nil {
+			cursor := games.Cursor()
+			for k, _ := cursor.Seek(getDateKey(from)); k != nil && string(k) <= string(getDateKey(to)); k, _ = cursor.Seek(getDateKey(from)) {
+				if err := cursor.Delete(); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := putDraws(tx, gameId, drawResults); err != nil {
+			return err
+		}
+
+		return tx.Bucket(monthsBucket).Put(getMonthKey(gameId, month, year), []byte(time.Now().Format(time.RFC3339)))
+	})
+}
+
+// HasMonth reports whether all the draws of a month are stored.
+func HasMonth(gameId string, month int, year int) bool {
+	db, err := getDB()
+	if err != nil {
+		return false
+	}
+
+	found := false
+	db.View(func(tx *bolt.Tx) error {
+		found = tx.Bucket(monthsBucket).Get(getMonthKey(gameId, month, year)) != nil
+		return nil
+	})
+
+	return found
+}
+
+// GetMonth returns the draws of a month, if the month is complete in the store.
+func GetMonth(gameId string, month int, year int) ([]models.DrawResult, bool) {
+	if !HasMonth(gameId, month, year) {
+		return nil, false
+	}
+
+	from, to := getMonthRange(month, year)
+	drawResults, err := GetDrawsBetween(gameId, from, to)
+	if err != nil {
+		logError(err)
+		return nil, false
+	}
+
+	return drawResults, true
+}
+
+// GetDraw returns the draw of a game on the given date.
+func GetDraw(gameId string, date time.Time) (*models.DrawResult, bool) {
+	drawResults, err := GetDrawsBetween(gameId, date, date)
+	if err != nil || len(drawResults) == 0 {
+		return nil, false
+	}
+
+	return &drawResults[0], true
+}
+
+// GetDrawsBetween returns the stored draws of a game between from and to (inclusive), oldest first.
+func GetDrawsBetween(gameId string, from time.Time, to time.Time) ([]models.DrawResult, error) {
+	db, err := getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	drawResults := []models.DrawResult{}
+	toKey := string(getDateKey(to))
+
+	err = db.View(func(tx *bolt.Tx) error {
+		games := tx.Bucket(drawsBucket).Bucket([]byte(gameId))
+		if games == nil {
+			return nil
+		}
+
+		cursor := games.Cursor()
+		for k, v := cursor.Seek(getDateKey(from)); k != nil && string(k) <= toKey; k, v = cursor.Next() {
+			var drawResult models.DrawResult
+			if err := json.Unmarshal(v, &drawResult); err != nil {
+				return err
+			}
+
+			drawResults = append(drawResults, drawResult)
+		}
+
+		return nil
+	})
+
+	return drawResults, err
+}
+
+// GetLatestDraws returns the last count stored draws of a game, newest first.
+func GetLatestDraws(gameId string, count int) ([]models.DrawResult, error) {
+	db, err := getDB()
+	if err != nil {
+		return nil, err
+	}
+
+	drawResults := []models.DrawResult{}
+
+	err = db.View(func(tx *bolt.Tx) error {
+		games := tx.Bucket(drawsBucket).Bucket([]byte(gameId))
+		if games == nil {
+			return nil
+		}
+
+		cursor := games.Cursor()
+		for k, v := cursor.Last(); k != nil && len(drawResults) < count; k, v = cursor.Prev() {
+			var drawResult models.DrawResult
+			if err := json.Unmarshal(v, &drawResult); err != nil {
+				return err
+			}
+
+			drawResults = append(drawResults, drawResult)
+		}
+
+		return nil
+	})
+
+	return drawResults, err
+}
+
+func SaveDrawAvailability(availability models.DrawAvailability) error {
+	db, err := getDB()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(availability)
+	if err != nil {
+		return err
+	}
+
+	return db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(availabilityBucket).Put([]byte(availability.GameId+"/"+availability.DrawDate), data)
+	})
+}
+
+// GetLatestDrawAvailability returns when the results of the last recorded draw of a game became available.
+func GetLatestDrawAvailability(gameId string) (*models.DrawAvailability, bool) {
+	db, err := getDB()
+	if err != nil {
+		return nil, false
+	}
+
+	var availability *models.DrawAvailability
+
+	db.View(func(tx *bolt.Tx) error {
+		prefix := []byte(gameId + "/")
+		cursor := tx.Bucket(availabilityBucket).Cursor()
+
+		// Keys sort by game and date, so the last draw of the game is right before the next prefix
+		k, v := cursor.Seek([]byte(gameId + "0"))
+		if k == nil {
+			k, v = cursor.Last()
+		} else {
+			k, v = cursor.Prev()
+		}
+
+		if k == nil || !bytes.HasPrefix(k, prefix) {
+			return nil
+		}
+
+		availability = &models.DrawAvailability{}
+		if err := json.Unmarshal(v, availability); err != nil {
+			availability = nil
+			logError(err)
+		}
+
+		return nil
+	})
+
+	return availability, availability != nil
+}
+
+// GetBackfillProgress returns the state of every month the backfill attempted, keyed by game and month.
+func GetBackfillProgress() map[string]models.BackfillMonth {
+	progress := map[string]models.BackfillMonth{}
+
+	db, err := getDB()
+	if err != nil {
+		return progress
+	}
+
+	db.View(func(tx *bolt.Tx) error {
+		if data := tx.Bucket(metaBucket).Get(backfillProgressKey); data != nil {
+			if err := json.Unmarshal(data, &progress); err != nil {
+				logError(err)
+			}
+		}
+
+		return nil
+	})
+
+	return progress
+}
+
+func SaveBackfillProgress(progress map[string]models.BackfillMonth) error {
+	db, err := getDB()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	return db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(metaBucket).Put(backfillProgressKey, data)
+	})
+}
+
+func logError(err error) {
+	logging.Error("store", err, "")
+}
@@ -0,0 +1,145 @@
+package store
+
+import (
+	"fmt"
+	"loto-suite/backend/models"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "store")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	dataDir = dir
+	code := m.Run()
+
+	Close()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func newStoreTestDraws(gameId string, dates ...string) []models.DrawResult {
+	drawResults := []models.DrawResult{}
+	for _, date := range dates {
+		drawResults = append(drawResults, models.DrawResult{GameId: gameId, GameDate: date})
+	}
+
+	return drawResults
+}
+
+func getDrawDates(drawResults []models.DrawResult) []string {
+	dates := []string{}
+	for _, drawResult := range drawResults {
+		dates = append(dates, drawResult.GameDate)
+	}
+
+	return dates
+}
+
+func TestSaveMonth(t *testing.T) {
+	if err := SaveDraws("month", newStoreTestDraws("month", "2024-03-03", "2024-03-31")); err != nil {
+		t.Fatal(err)
+	}
+
+	if HasMonth("month", 3, 2024) {
+		t.Fatal("expected a month of loose draws not to be complete")
+	}
+
+	if _, found := GetMonth("month", 3, 2024); found {
+		t.Fatal("expected no draws from an incomplete month")
+	}
+
+	// The month is replaced as a whole, so the draw of 2024-03-03 is dropped
+	if err := SaveMonth("month", 3, 2024, newStoreTestDraws("month", "2024-03-28", "2024-03-31")); err != nil {
+		t.Fatal(err)
+	}
+
+	drawResults, found := GetMonth("month", 3, 2024)
+	if !found {
+		t.Fatal("expected the month to be complete")
+	}
+
+	if dates := getDrawDates(drawResults); fmt.Sprint(dates) != "[2024-03-28 2024-03-31]" {
+		t.Errorf("expected the saved draws, got %v", dates)
+	}
+
+	if err := SaveMonth("month", 2, 2024, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if drawResults, found := GetMonth("month", 2, 2024); !found || len(drawResults) != 0 {
+		t.Errorf("expected an empty complete month, got %v, %v", drawResults, found)
+	}
+}
+
+func TestGetDrawsBetween(t *testing.T) {
+	if err := SaveDraws("range", newStoreTestDraws("range", "2024-04-04", "2024-03-28", "2024-03-31", "2024-04-07")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		expected string
+	}{
+		{"across months", time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 5, 0, 0, 0, 0, time.UTC), "[2024-03-31 2024-04-04]"},
+		{"inclusive", time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 7, 0, 0, 0, 0, time.UTC), "[2024-03-28 2024-03-31 2024-04-04 2024-04-07]"},
+		{"single day", time.Date(2024, 4, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 4, 0, 0, 0, 0, time.UTC), "[2024-04-04]"},
+		{"no draws", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC), "[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drawResults, err := GetDrawsBetween("range", tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if dates := fmt.Sprint(getDrawDates(drawResults)); dates != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, dates)
+			}
+		})
+	}
+
+	if _, found := GetDraw("range", time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)); !found {
+		t.Error("expected the draw of 2024-03-31")
+	}
+
+	if drawResults, err := GetDrawsBetween("unknown", time.Time{}, time.Now()); err != nil || len(drawResults) != 0 {
+		t.Errorf("expected no draws of an unknown game, got %v, %v", drawResults, err)
+	}
+}
+
+func TestGetLatestDraws(t *testing.T) {
+	if err := SaveDraws("latest", newStoreTestDraws("latest", "2024-03-28", "2024-04-04", "2024-03-31")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		count    int
+		expected string
+	}{
+		{1, "[2024-04-04]"},
+		{2, "[2024-04-04 2024-03-31]"},
+		{10, "[2024-04-04 2024-03-31 2024-03-28]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.count), func(t *testing.T) {
+			drawResults, err := GetLatestDraws("latest", tt.count)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if dates := fmt.Sprint(getDrawDates(drawResults)); dates != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, dates)
+			}
+		})
+	}
+}
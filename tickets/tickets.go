@@ -0,0 +1,166 @@
+package tickets
+
+import (
+	"encoding/json"
+	"loto-suite/backend/logging"
+	"loto-suite/backend/models"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// store keeps the saved tickets in memory and one JSON file per ticket on disk,
+// so pending tickets survive restarts of the server.
+type store struct {
+	tickets    map[string]models.SavedTicket
+	mutex      sync.RWMutex
+	ticketsDir string
+}
+
+var (
+	globalStore *store
+	once        sync.Once
+)
+
+func getStore() *store {
+	once.Do(func() {
+		if _, filename, _, ok := runtime.Caller(0); ok {
+			ticketsDir := filepath.Join(filepath.Dir(filename), "tickets")
+
+			globalStore = &store{
+				tickets:    make(map[string]models.SavedTicket),
+				ticketsDir: ticketsDir,
+			}
+
+			_ = os.MkdirAll(ticketsDir, 0755)
+			globalStore.loadFromDisk()
+		}
+	})
+
+	return globalStore
+}
+
+func Save(ticket models.SavedTicket) error {
+	return getStore().save(ticket)
+}
+
+func (s *store) save(ticket models.SavedTicket) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, err := json.MarshalIndent(ticket, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(s.getTicketFilePath(ticket.Id), data, 0644); err != nil {
+		return err
+	}
+
+	s.tickets[ticket.Id] = ticket
+
+	return nil
+}
+
+func Get(id string) (models.SavedTicket, bool) {
+	return getStore().get(id)
+}
+
+func (s *store) get(id string) (models.SavedTicket, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	ticket, found := s.tickets[id]
+	return ticket, found
+}
+
+// List returns the saved tickets in the given state (all of them when state is empty),
+// oldest first.
+func List(state string) []models.SavedTicket {
+	return getStore().list(state)
+}
+
+func (s *store) list(state string) []models.SavedTicket {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	tickets := []models.SavedTicket{}
+	for _, ticket := range s.tickets {
+		if state == "" || ticket.State == state {
+			tickets = append(tickets, ticket)
+		}
+	}
+
+	sort.Slice(tickets, func(i, j int) bool {
+		return tickets[i].CreatedAt.Before(tickets[j].CreatedAt)
+	})
+
+	return tickets
+}
+
+// GetMany returns the saved tickets with the given ids that are in the given state (all of
+// them when state is empty), oldest first. Unknown ids are left out.
+func GetMany(ids []string, state string) []models.SavedTicket {
+	return getStore().getMany(ids, state)
+}
+
+func (s *store) getMany(ids []string, state string) []models.SavedTicket {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	tickets := []models.SavedTicket{}
+	seen := map[string]bool{}
+	for _, id := range ids {
+		ticket, found := s.tickets[id]
+		if !found || seen[id] || (state != "" && ticket.State != state) {
+			continue
+		}
+
+		seen[id] = true
+		tickets = append(tickets, ticket)
+	}
+
+	sort.Slice(tickets, func(i, j int) bool {
+		return tickets[i].CreatedAt.Before(tickets[j].CreatedAt)
+	})
+
+	return tickets
+}
+
+func (s *store) getTicketFilePath(id string) string {
+	return filepath.Join(s.ticketsDir, id+".json")
+}
+
+func (s *store) loadFromDisk() {
+	files, err := os.ReadDir(s.ticketsDir)
+	if err != nil {
+		logError(err)
+		return
+	}
+
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.ticketsDir, file.Name()))
+		if err != nil {
+			logError(err)
+			continue
+		}
+
+		var ticket models.SavedTicket
+		if err := json.Unmarshal(data, &ticket); err != nil {
+			logError(err)
+			continue
+		}
+
+		s.tickets[ticket.Id] = ticket
+	}
+}
+
+func logError(err error) {
+	logging.Error("tickets", err, "")
+}
@@ -0,0 +1,264 @@
+package utils
+
+import (
+	"fmt"
+	"loto-suite/backend/generics"
+	"loto-suite/backend/logging"
+	"loto-suite/backend/models"
+	"loto-suite/backend/store"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// backfillMaxEmptyMonths is how many consecutive months without draws end the backfill
+// of a game that has no prima_extragere configured.
+const backfillMaxEmptyMonths = 12
+
+// backfillMaxFailedMonths is how many consecutive failed months stop the backfill of a game,
+// as loto.ro is most likely unavailable. The run can be resumed later.
+const backfillMaxFailedMonths = 5
+
+type BackfillOptions struct {
+	GameIds         []string
+	Delay           time.Duration
+	RetryFailedOnly bool
+	Progress        func(month models.BackfillMonth)
+}
+
+// Backfill stores the draw results of every closed month of the given games (all of them when
+// GameIds is empty), walking back from the last closed month to the first draw of each game.
+// Months already stored are skipped, so an interrupted run resumes where it stopped; failed
+// months are recorded in the progress file and attempted again on the next run.
+func Backfill(options BackfillOptions) (*models.BackfillReport, error) {
+	if !IsDrawSourceStored() {
+		return nil, fmt.Errorf("the backfill stores the results of the %s draw source only, use import for files", DrawSourceScraper)
+	}
+
+	games := []*models.Game{}
+	if len(options.GameIds) == 0 {
+		games = models.Games
+	}
+
+	for _, gameId := range options.GameIds {
+		game, err := GetGameById(gameId)
+		if err != nil {
+			return nil, err
+		}
+
+		games = append(games, game)
+	}
+
+	run := backfillRun{
+		options:  options,
+		progress: store.GetBackfillProgress(),
+		report: models.BackfillReport{
+			Failed: []models.BackfillMonth{},
+		},
+	}
+
+	for _, game := range games {
+		firstDrawDate, err := getFirstDrawDate(game)
+		if err != nil {
+			return nil, fmt.Errorf("game %s: %w", game.Id, err)
+		}
+
+		if options.RetryFailedOnly {
+			for _, failed := range getFailedBackfillMonths(run.progress, game.Id) {
+				if store.HasMonth(game.Id, failed.Month, failed.Year) {
+					run.report.Skipped++
+					continue
+				}
+
+				if _, err := run.attempt(game, failed.Month, failed.Year, firstDrawDate.IsZero()); err != nil {
+					return nil, err
+				}
+			}
+
+			continue
+		}
+
+		emptyMonths, failedMonths := 0, 0
+		for date := getLastClosedMonth(time.Now()); ; date = date.AddDate(0, -1, 0) {
+			if !firstDrawDate.IsZero() && date.Before(getMonthStart(firstDrawDate)) {
+				break
+			}
+
+			if firstDrawDate.IsZero() && emptyMonths >= backfillMaxEmptyMonths {
+				break
+			}
+
+			if failedMonths >= backfillMaxFailedMonths {
+				logging.Error("backfill", fmt.Errorf("%s: stopped after %d consecutive failed months", game.Id, failedMonths), "")
+				break
+			}
+
+			month, year := int(date.Month()), date.Year()
+
+			if store.HasMonth(game.Id, month, year) {
+				run.report.Skipped++
+				emptyMonths = 0
+				continue
+			}
+
+			result, err := run.attempt(game, month, year, firstDrawDate.IsZero())
+			if err != nil {
+				return nil, err
+			}
+
+			switch result.State {
+			case models.BackfillMonthStored:
+				emptyMonths, failedMonths = 0, 0
+			case models.BackfillMonthEmpty:
+				emptyMonths++
+				failedMonths = 0
+			case models.BackfillMonthFailed:
+				failedMonths++
+			}
+		}
+	}
+
+	return &run.report, nil
+}
+
+// backfillRun holds the state of one Backfill call shared by the months it attempts.
+type backfillRun struct {
+	options       BackfillOptions
+	progress      map[string]models.BackfillMonth
+	report        models.BackfillReport
+	lastRequestAt time.Time
+}
+
+// attempt backfills one month, waiting options.Delay since the previous request, and records the
+// outcome in the report and the progress file.
+func (r *backfillRun) attempt(game *models.Game, month int, year int, skipEmpty bool) (models.BackfillMonth, error) {
+	if wait := r.options.Delay - time.Since(r.lastRequestAt); wait > 0 {
+		time.Sleep(wait)
+	}
+	r.lastRequestAt = time.Now()
+
+	key := getBackfillKey(game.Id, month, year)
+
+	result := backfillMonth(game, month, year, skipEmpty)
+	result.Attempts = r.progress[key].Attempts + 1
+	r.progress[key] = result
+
+	switch result.State {
+	case models.BackfillMonthStored:
+		r.report.Stored++
+	case models.BackfillMonthEmpty:
+		r.report.Empty++
+	case models.BackfillMonthFailed:
+		r.report.Failed = append(r.report.Failed, result)
+		logging.Error("backfill", fmt.Errorf("%s %02d/%d: %s", game.Id, month, year, result.Error), "")
+	}
+
+	if err := store.SaveBackfillProgress(r.progress); err != nil {
+		return result, fmt.Errorf("failed to save backfill progress: %w", err)
+	}
+
+	if r.options.Progress != nil {
+		r.options.Progress(result)
+	}
+
+	return result, nil
+}
+
+// getFailedBackfillMonths returns the months of a game whose last backfill attempt failed, newest first.
+func getFailedBackfillMonths(progress map[string]models.BackfillMonth, gameId string) []models.BackfillMonth {
+	failed := []models.BackfillMonth{}
+	for _, month := range progress {
+		if month.GameId == gameId && month.State == models.BackfillMonthFailed {
+			failed = append(failed, month)
+		}
+	}
+
+	sort.Slice(failed, func(i int, j int) bool {
+		if failed[i].Year != failed[j].Year {
+			return failed[i].Year > failed[j].Year
+		}
+
+		return failed[i].Month > failed[j].Month
+	})
+
+	return failed
+}
+
+// backfillMonth fetches and stores one month. Empty months are stored only when the first draw of
+// the game is known; otherwise they may just be older than the archive of the results page.
+func backfillMonth(game *models.Game, month int, year int, skipEmpty bool) models.BackfillMonth {
+	result := models.BackfillMonth{
+		GameId:      game.Id,
+		Month:       month,
+		Year:        year,
+		AttemptedAt: time.Now(),
+	}
+
+	drawResults, err := GetDrawSource().GetDrawResults(game, strconv.Itoa(month), strconv.Itoa(year))
+	if err != nil {
+		result.State = models.BackfillMonthFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	result.DrawsCount = len(drawResults)
+	result.State = models.BackfillMonthStored
+	if len(drawResults) == 0 {
+		result.State = models.BackfillMonthEmpty
+		if skipEmpty {
+			return result
+		}
+	}
+
+	normalizeDrawResults(drawResults, game)
+
+	if len(drawResults) > 0 && !isMonthComplete(drawResults, month, year, time.Now()) {
+		// Kept for now and retried until the last draw of the month is fully published
+		if err := store.SaveDraws(game.Id, drawResults); err != nil {
+			logging.Error("store", err, "")
+		}
+
+		result.State = models.BackfillMonthFailed
+		result.Error = "the results of the last draw of the month are not fully published yet"
+		return result
+	}
+
+	if err := store.SaveMonth(game.Id, month, year, drawResults); err != nil {
+		result.State = models.BackfillMonthFailed
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+func getFirstDrawDate(game *models.Game) (time.Time, error) {
+	if game.FirstDrawDate == "" {
+		return time.Time{}, nil
+	}
+
+	firstDrawDate, err := generics.TryParseDate(game.FirstDrawDate)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid prima_extragere: %w", err)
+	}
+
+	return firstDrawDate, nil
+}
+
+func getBackfillKey(gameId string, month int, year int) string {
+	return fmt.Sprintf("%s_%d_%d", gameId, month, year)
+}
+
+func getMonthStart(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+}
+
+// getLastClosedMonth returns the start of the last month whose draws can no longer change:
+// a month is closed one day after it ends, once the results of its last draw are published.
+func getLastClosedMonth(now time.Time) time.Time {
+	return getMonthStart(now.AddDate(0, 0, -1)).AddDate(0, -1, 0)
+}
+
+func isClosedMonth(month int, year int, now time.Time) bool {
+	lastClosedMonth := getLastClosedMonth(now)
+	return year < lastClosedMonth.Year() || (year == lastClosedMonth.Year() && month <= int(lastClosedMonth.Month()))
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"loto-suite/backend/models"
+	"testing"
+	"time"
+)
+
+func TestGetLastClosedMonth(t *testing.T) {
+	tests := []struct {
+		now      time.Time
+		expected string
+	}{
+		{time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC), "2024-02"},
+		{time.Date(2024, 4, 2, 10, 0, 0, 0, time.UTC), "2024-03"},
+		{time.Date(2024, 4, 30, 10, 0, 0, 0, time.UTC), "2024-03"},
+		{time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), "2023-11"},
+		{time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC), "2023-12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.now.Format("2006-01-02"), func(t *testing.T) {
+			if month := getLastClosedMonth(tt.now).Format("2006-01"); month != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, month)
+			}
+		})
+	}
+}
+
+func TestIsClosedMonth(t *testing.T) {
+	now := time.Date(2024, 4, 10, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		month    int
+		year     int
+		expected bool
+	}{
+		{3, 2024, true},
+		{12, 2023, true},
+		{4, 2024, false},
+		{1, 2025, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d-%02d", tt.year, tt.month), func(t *testing.T) {
+			if closed := isClosedMonth(tt.month, tt.year, now); closed != tt.expected {
+				t.Errorf("expected closed %v, got %v", tt.expected, closed)
+			}
+		})
+	}
+}
+
+func TestGetFailedBackfillMonths(t *testing.T) {
+	progress := map[string]models.BackfillMonth{}
+	for _, month := range []models.BackfillMonth{
+		{GameId: "649", Month: 3, Year: 2023, State: models.BackfillMonthFailed},
+		{GameId: "649", Month: 11, Year: 2022, State: models.BackfillMonthFailed},
+		{GameId: "649", Month: 12, Year: 2023, State: models.BackfillMonthFailed},
+		{GameId: "649", Month: 6, Year: 2023, State: models.BackfillMonthStored},
+		{GameId: "joker", Month: 5, Year: 2023, State: models.BackfillMonthFailed},
+	} {
+		progress[getBackfillKey(month.GameId, month.Month, month.Year)] = month
+	}
+
+	actual := []string{}
+	for _, month := range getFailedBackfillMonths(progress, "649") {
+		actual = append(actual, fmt.Sprintf("%d-%02d", month.Year, month.Month))
+	}
+
+	if expected := []string{"2023-12", "2023-03", "2022-11"}; fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestBackfillMonthWithoutStoring(t *testing.T) {
+	source := newFakeDrawSource()
+	source.errs["649_3_2024"] = errors.New("loto.ro is unavailable")
+	useDrawSource(t, source)
+
+	game, err := GetGameById("649")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		month    int
+		state    string
+		hasError bool
+	}{
+		{"failed request", 3, models.BackfillMonthFailed, true},
+		{"month without draws", 2, models.BackfillMonthEmpty, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := backfillMonth(game, tt.month, 2024, true)
+
+			if result.State != tt.state {
+				t.Errorf("expected state %s, got %s", tt.state, result.State)
+			}
+
+			if (result.Error != "") != tt.hasError {
+				t.Errorf("expected an error %v, got %q", tt.hasError, result.Error)
+			}
+
+			if result.GameId != "649" || result.Month != tt.month || result.Year != 2024 {
+				t.Errorf("expected the month 649 %d/2024, got %+v", tt.month, result)
+			}
+		})
+	}
+}
+
+func TestBackfillRequiresTheScraper(t *testing.T) {
+	useDrawSource(t, newFakeDrawSource())
+
+	if _, err := Backfill(BackfillOptions{GameIds: []string{"649"}}); err == nil {
+		t.Error("expected the backfill to refuse a draw source other than the scraper")
+	}
+}
@@ -0,0 +1,199 @@
+package utils
+
+import (
+	"fmt"
+	"loto-suite/backend/generics"
+	"loto-suite/backend/models"
+	"loto-suite/backend/store"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// maxBacktestFetchedMonths caps the months of a backtest that are not in the local store and have
+// to be fetched from the draw source one after another; the rest are skipped and the result is
+// marked partial until they are backfilled.
+const maxBacktestFetchedMonths = 3
+
+// Backtest runs the ticket in request against every published draw of the game between
+// DateFrom and DateTo (inclusive) and reports the draws it would have won. The special draw
+// is scored and paid for only when request.SpecialDraw is set.
+func Backtest(request models.BacktestRequest) (*models.BacktestResult, error) {
+	dateFrom, err := generics.TryParseDate(request.DateFrom)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date_from format")
+	}
+
+	dateTo := time.Now()
+	if request.DateTo != "" {
+		if dateTo, err = generics.TryParseDate(request.DateTo); err != nil {
+			return nil, fmt.Errorf("invalid date_to format")
+		}
+	}
+
+	dateTo = time.Date(dateTo.Year(), dateTo.Month(), dateTo.Day(), 0, 0, 0, 0, time.UTC)
+	if dateTo.Before(dateFrom) {
+		return nil, fmt.Errorf("date_from must not be after date_to")
+	}
+
+	ticket, err := newTicketCheck(models.CheckRequest{
+		GameId:      request.GameId,
+		LuckyNumber: request.LuckyNumber,
+		Date:        dateFrom.Format(generics.GoDateFormat),
+		SpecialDraw: request.SpecialDraw,
+		Variants:    request.Variants,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a given stake, a draw costs the ticket price, plus the special draw option when it was held
+	stakeRegular, stakeSpecial := request.StakePerDraw, request.StakePerDraw
+	if request.StakePerDraw == 0 {
+		stakeRegular, stakeSpecial = getBacktestStakes(ticket.request)
+		request.StakePerDraw = stakeRegular
+	}
+
+	// Months before the first draw of the game have nothing to fetch
+	if firstDrawDate, err := getFirstDrawDate(ticket.game); err == nil && dateFrom.Before(firstDrawDate) {
+		dateFrom = firstDrawDate
+	}
+
+	monthsDrawResults, monthsSkipped, err := getBacktestDrawResults(ticket.game, dateFrom, dateTo)
+	if err != nil {
+		return nil, err
+	}
+
+	result := models.BacktestResult{
+		GameId:        ticket.game.Id,
+		DateFrom:      dateFrom.Format(generics.GoDateFormat),
+		DateTo:        dateTo.Format(generics.GoDateFormat),
+		WinningDraws:  []models.BacktestDraw{},
+		StakePerDraw:  request.StakePerDraw,
+		Partial:       len(monthsSkipped) > 0,
+		MonthsSkipped: monthsSkipped,
+	}
+
+	for _, drawResults := range monthsDrawResults {
+		for _, drawResult := range getDrawResultsBetween(drawResults, dateFrom, dateTo) {
+			if request.SpecialDraw && drawResult.VariantSpecial != nil {
+				result.StakeTotal += stakeSpecial
+			} else {
+				drawResult.VariantSpecial = nil
+				drawResult.WinCategoriesVariantSpecial = nil
+				result.StakeTotal += stakeRegular
+			}
+
+			checkResult := ticket.checkDraw(drawResult)
+			result.DrawsChecked++
+
+			if !checkResult.IsCastigator {
+				continue
+			}
+
+			result.DrawsWon++
+			result.WinsTotal += checkResult.WinsTotal
+			result.TaxTotal += checkResult.TaxTotal
+			result.WinsTotalNet += checkResult.WinsTotalNet
+			result.WinningDraws = append(result.WinningDraws, models.BacktestDraw{
+				Date:                        drawResult.GameDate,
+				WinsCumulatedVariantRegular: checkResult.WinsCumulatedVariantRegular,
+				WinsCumulatedVariantSpecial: checkResult.WinsCumulatedVariantSpecial,
+				WinsCumulatedLuckyNumber:    checkResult.WinsCumulatedLuckyNumber,
+				WinsTotal:                   checkResult.WinsTotal,
+				TaxTotal:                    checkResult.TaxTotal,
+				WinsTotalNet:                checkResult.WinsTotalNet,
+			})
+		}
+	}
+
+	result.NetResult = result.WinsTotal - result.StakeTotal
+	if result.StakeTotal > 0 {
+		result.ReturnRate = result.WinsTotal / result.StakeTotal
+	}
+
+	return &result, nil
+}
+
+// getBacktestStakes returns the cost of the ticket for one draw, without and with the special draw option.
+func getBacktestStakes(request models.CheckRequest) (float64, float64) {
+	request.SpecialDraw = false
+	cost, err := CalculateTicketCost(request)
+	if err != nil {
+		return 0, 0
+	}
+
+	stakeRegular := cost.CostPerDraw
+
+	request.SpecialDraw = true
+	if cost, err = CalculateTicketCost(request); err != nil {
+		return stakeRegular, stakeRegular
+	}
+
+	return stakeRegular, cost.CostPerDraw
+}
+
+// getBacktestDrawResults returns the draws of every month between from and to. Months in the local
+// store are read from it; of the others, the latest maxBacktestFetchedMonths are fetched and the
+// rest are skipped and returned as YYYY-MM. Fixtures and imports are local, so all their months
+// are read from the draw source.
+func getBacktestDrawResults(game *models.Game, from time.Time, to time.Time) ([][]models.DrawResult, []string, error) {
+	stored := IsDrawSourceStored()
+	months := []time.Time{}
+	for m := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); !m.After(to); m = m.AddDate(0, 1, 0) {
+		months = append(months, m)
+	}
+
+	resolver := newDrawResultsResolver()
+	monthsDrawResults := make([][]models.DrawResult, len(months))
+	monthsSkipped := []string{}
+	fetchedMonths := 0
+	for i := len(months) - 1; i >= 0; i-- {
+		m := months[i]
+		if stored {
+			if drawResults, found := store.GetMonth(game.Id, int(m.Month()), m.Year()); found {
+				normalizeDrawResults(drawResults, game)
+				monthsDrawResults[i] = drawResults
+				continue
+			}
+
+			if fetchedMonths >= maxBacktestFetchedMonths {
+				monthsSkipped = append(monthsSkipped, m.Format("2006-01"))
+				continue
+			}
+
+			fetchedMonths++
+		}
+
+		drawResults, err := resolver.month(game.Id, strconv.Itoa(int(m.Month())), strconv.Itoa(m.Year()))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		monthsDrawResults[i] = drawResults
+	}
+
+	sort.Strings(monthsSkipped)
+
+	return monthsDrawResults, monthsSkipped, nil
+}
+
+// getDrawResultsBetween returns the draws between from and to (inclusive), oldest first.
+func getDrawResultsBetween(drawResults []models.DrawResult, from time.Time, to time.Time) []models.DrawResult {
+	filtered := []models.DrawResult{}
+	for _, drawResult := range drawResults {
+		date, err := generics.TryParseDate(drawResult.GameDate)
+		if err != nil || date.Before(from) || date.After(to) {
+			continue
+		}
+
+		filtered = append(filtered, drawResult)
+	}
+
+	sort.Slice(filtered, func(i int, j int) bool {
+		return filtered[i].GameDate < filtered[j].GameDate
+	})
+
+	return filtered
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"loto-suite/backend/models"
+	"testing"
+)
+
+func TestBacktest(t *testing.T) {
+	tests := []struct {
+		name         string
+		dateFrom     string
+		dateTo       string
+		drawsChecked int
+		drawsWon     int
+		winsTotal    float64
+		stakeTotal   float64
+		monthsCalled int
+	}{
+		{"range across months", "2024-03-01", "2024-04-30", 2, 2, 330, 14, 2},
+		{"range within a month", "2024-04-01", "2024-04-30", 1, 1, 300, 7, 1},
+		{"range before the first draw", "1992-06-01", "1993-01-31", 0, 0, 0, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := newFakeDrawSource(newTestDraw("2024-03-28", 1, 2, 3, 10, 11, 12), newTestDraw("2024-04-04", 1, 2, 3, 4, 11, 12))
+			useDrawSource(t, source)
+
+			result, err := Backtest(models.BacktestRequest{
+				GameId:   "649",
+				DateFrom: tt.dateFrom,
+				DateTo:   tt.dateTo,
+				Variants: newTestRequest("", 1, 2, 3, 4, 5, 6).Variants,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.DrawsChecked != tt.drawsChecked || result.DrawsWon != tt.drawsWon {
+				t.Errorf("expected %d draws checked and %d won, got %d and %d", tt.drawsChecked, tt.drawsWon, result.DrawsChecked, result.DrawsWon)
+			}
+
+			if result.WinsTotal != tt.winsTotal || result.StakeTotal != tt.stakeTotal {
+				t.Errorf("expected wins %v for a stake of %v, got %v and %v", tt.winsTotal, tt.stakeTotal, result.WinsTotal, result.StakeTotal)
+			}
+
+			if result.Partial || len(result.MonthsSkipped) > 0 {
+				t.Errorf("expected a complete result, got months skipped %v", result.MonthsSkipped)
+			}
+
+			if len(source.calls) != tt.monthsCalled {
+				t.Errorf("expected %d months fetched, got %v", tt.monthsCalled, source.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"loto-suite/backend/generics"
+	"loto-suite/backend/models"
+)
+
+// AddWinBreakdown fills the Breakdown of every played variant (and systematic combination)
+// and of the lucky number with the categories won, their amounts and the matched numbers.
+func AddWinBreakdown(checkResult *models.CheckResult) {
+	if checkResult == nil || checkResult.DrawResult == nil {
+		return
+	}
+
+	for i := range checkResult.VarianteJucate {
+		addVariantWinBreakdown(&checkResult.VarianteJucate[i], checkResult.DrawResult)
+	}
+
+	if checkResult.LuckyNumber != nil {
+		checkResult.LuckyNumber.Breakdown = &models.WinBreakdown{
+			WinsLuckyNumber: getWinDetails(checkResult.LuckyNumber.Wins, checkResult.DrawResult.WinCategoriesLuckyNumber),
+		}
+	}
+}
+
+func AddMultiDrawWinBreakdown(result *models.MultiDrawCheckResult) {
+	if result == nil {
+		return
+	}
+
+	for _, draw := range result.Draws {
+		AddWinBreakdown(draw.Result)
+	}
+}
+
+func addVariantWinBreakdown(varianta *models.Variant, drawResult *models.DrawResult) {
+	for i := range varianta.Combinations {
+		addVariantWinBreakdown(&varianta.Combinations[i], drawResult)
+	}
+
+	varianta.Breakdown = &models.WinBreakdown{
+		WinsRegular:             getWinDetails(varianta.WinsRegular, drawResult.WinCategoriesVariantRegular),
+		WinsSpecial:             getWinDetails(varianta.WinsSpecial, drawResult.WinCategoriesVariantSpecial),
+		MatchedNumbersRegular:   getMatchedNumbers(varianta.Numbers, drawResult.VariantRegular, false),
+		MatchedNumbersSpecial:   getMatchedNumbers(varianta.Numbers, drawResult.VariantSpecial, false),
+		MatchedSecondaryRegular: getMatchedNumbers(varianta.SecondaryNumbers, drawResult.VariantRegular, true),
+		MatchedSecondarySpecial: getMatchedNumbers(varianta.SecondaryNumbers, drawResult.VariantSpecial, true),
+	}
+}
+
+func getWinDetails(castiguri []models.Win, categoriiCastig []models.WinCategory) []models.WinDetail {
+	details := []models.WinDetail{}
+	for _, castig := range castiguri {
+		if !castig.IsWinner {
+			continue
+		}
+
+		detail := models.WinDetail{
+			Id:          castig.Id,
+			Description: castig.Description,
+			State:       models.PrizeStatePending,
+		}
+
+		if categorie, found := generics.FindFirst(categoriiCastig, func(c models.WinCategory) bool {
+			return c.Id == castig.Id
+		}); found {
+			detail.Amount = categorie.Amount
+			detail.State = categorie.State
+		}
+
+		details = append(details, detail)
+	}
+
+	return details
+}
+
+func getMatchedNumbers(numereJucate []models.Number, variantaExtrasa *models.Variant, secondary bool) []int {
+	matched := []int{}
+	if variantaExtrasa == nil {
+		return matched
+	}
+
+	numereExtrase := variantaExtrasa.Numbers
+	if secondary {
+		numereExtrase = variantaExtrasa.SecondaryNumbers
+	}
+
+	for _, numar := range numereJucate {
+		if ContainsNumarByValue(numereExtrase, numar) {
+			matched = append(matched, numar.Value)
+		}
+	}
+
+	return matched
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"loto-suite/backend/generics"
+	"loto-suite/backend/logging"
+	"loto-suite/backend/models"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+const MaxBatchSize = 100
+
+// CheckTickets checks every request independently and returns one item per request, in
+// request order. Requests are processed grouped by game and month so that draw results are
+// resolved once per group; a failing request only sets the Error of its own item.
+func CheckTickets(requests []models.CheckRequest) []models.BatchCheckItem {
+	items := make([]models.BatchCheckItem, len(requests))
+	for i := range requests {
+		items[i].Index = i
+	}
+
+	order := make([]int, len(requests))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a int, b int) bool {
+		return getBatchGroupKey(requests[order[a]]) < getBatchGroupKey(requests[order[b]])
+	})
+
+	resolver := newDrawResultsResolver()
+	for _, i := range order {
+		checkBatchItem(requests[i], resolver, &items[i])
+	}
+
+	return items
+}
+
+func checkBatchItem(request models.CheckRequest, resolver *drawResultsResolver, item *models.BatchCheckItem) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err := fmt.Errorf("failed to check ticket %d: %v", item.Index, rec)
+			logging.Error("be", fmt.Errorf("%w\n%s", err, debug.Stack()), "")
+			item.Error = err.Error()
+		}
+	}()
+
+	var err error
+	if GetDrawCount(request) > 1 {
+		item.MultiDrawResult, err = checkTicketMultiDraw(request, resolver)
+	} else {
+		item.Result, err = checkTicket(request, resolver)
+	}
+
+	if err != nil {
+		item.Error = err.Error()
+
+		var validationErr *TicketValidationError
+		if errors.As(err, &validationErr) {
+			item.ValidationErrors = validationErr.Errors
+		}
+	}
+}
+
+func getBatchGroupKey(request models.CheckRequest) string {
+	gameId := strings.ToLower(strings.TrimSpace(request.GameId))
+
+	date, err := generics.TryParseDate(request.Date)
+	if err != nil {
+		return gameId
+	}
+
+	return fmt.Sprintf("%s_%04d_%02d", gameId, date.Year(), int(date.Month()))
+}
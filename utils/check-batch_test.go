@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"fmt"
+	"loto-suite/backend/models"
+	"testing"
+)
+
+// panickingDrawSource panics while resolving the months listed in panics.
+type panickingDrawSource struct {
+	*fakeDrawSource
+	panics map[string]bool
+}
+
+func (s *panickingDrawSource) GetDrawResults(game *models.Game, month string, year string) ([]models.DrawResult, error) {
+	if s.panics[fmt.Sprintf("%s_%s_%s", game.Id, month, year)] {
+		panic("unexpected page")
+	}
+
+	return s.fakeDrawSource.GetDrawResults(game, month, year)
+}
+
+func TestCheckTickets(t *testing.T) {
+	source := &panickingDrawSource{
+		fakeDrawSource: newFakeDrawSource(newTestDraw("2024-03-28", 1, 2, 3, 10, 11, 12)),
+		panics:         map[string]bool{"649_5_2024": true},
+	}
+	source.errs["649_4_2024"] = fmt.Errorf("%w: www.loto.ro", ErrCircuitOpen)
+	useDrawSource(t, source)
+
+	requests := []models.CheckRequest{
+		newTestRequest("2024-04-04", 1, 2, 3, 4, 5, 6),
+		newTestRequest("2024-03-28", 1, 2, 3, 4, 5, 6),
+		newTestRequest("2024-03-28", 1, 2, 3),
+		newTestRequest("2024-05-02", 1, 2, 3, 4, 5, 6),
+		newTestRequest("2024-04-07", 1, 2, 3, 4, 5, 6),
+		newTestRequest("2024-03-31", 1, 2, 3, 4, 5, 6),
+	}
+
+	expected := []struct {
+		checked    bool
+		failed     bool
+		validation string
+	}{
+		{failed: true},
+		{checked: true},
+		{failed: true, validation: models.ValidationCodeTooFewNumbers},
+		{failed: true},
+		{failed: true},
+		{failed: true},
+	}
+
+	items := CheckTickets(requests)
+	if len(items) != len(requests) {
+		t.Fatalf("expected %d items, got %d", len(requests), len(items))
+	}
+
+	for i, item := range items {
+		if item.Index != i {
+			t.Errorf("item %d: expected index %d, got %d", i, i, item.Index)
+		}
+
+		if (item.Result != nil) != expected[i].checked {
+			t.Errorf("item %d: expected checked %v, got result %+v", i, expected[i].checked, item.Result)
+		}
+
+		if (item.Error != "") != expected[i].failed {
+			t.Errorf("item %d: expected failed %v, got error %q", i, expected[i].failed, item.Error)
+		}
+
+		codes := []string{}
+		for _, validationError := range item.ValidationErrors {
+			codes = append(codes, validationError.Code)
+		}
+
+		if expected[i].validation != "" && fmt.Sprint(codes) != fmt.Sprint([]string{expected[i].validation}) {
+			t.Errorf("item %d: expected validation code %s, got %v", i, expected[i].validation, codes)
+		}
+	}
+
+	if calls := source.calls["649_4_2024"]; calls != 1 {
+		t.Errorf("expected the failing month to be fetched once, got %d", calls)
+	}
+}
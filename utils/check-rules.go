@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"loto-suite/backend/models"
+	"strconv"
+)
+
+// ruleGameChecker scores tickets using the prize category rules of the game definition.
+// It is registered for every configured game that has no dedicated GameChecker.
+type ruleGameChecker struct{}
+
+func (ruleGameChecker) CheckVariant(variantaJucata *models.Variant, variantaExtrasa *models.Variant, game *models.Game) {
+	if variantaJucata == nil || variantaExtrasa == nil {
+		return
+	}
+
+	secondaryNumbersCount := getSecondaryNumbersCount(game)
+
+	isValidTicket := len(variantaJucata.Numbers) >= game.VariantMinNumbersCount && len(variantaJucata.SecondaryNumbers) == secondaryNumbersCount
+	isValidDraw := variantaExtrasa.Id != -1 && len(variantaExtrasa.Numbers) == game.VariantDrawNumbersCount && len(variantaExtrasa.SecondaryNumbers) == secondaryNumbersCount
+
+	if !isValidTicket || !isValidDraw {
+		switch variantaExtrasa.Id {
+		case 1:
+			variantaJucata.WinsRegular = getDefaultCategoriiCastigVariante(game)
+		case 2:
+			variantaJucata.WinsSpecial = getDefaultCategoriiCastigVariante(game)
+		}
+
+		return
+	}
+
+	markWinningNumbers(variantaJucata.Numbers, variantaExtrasa.Numbers)
+	secondaryMatchCount := markWinningNumbers(variantaJucata.SecondaryNumbers, variantaExtrasa.SecondaryNumbers)
+
+	foundWinner := false
+	for _, categorie := range game.VariantCategories {
+		isWinner := !foundWinner && isVariantCategoryMatch(categorie, variantaJucata.Numbers, variantaExtrasa.Numbers, secondaryMatchCount)
+
+		castig := models.Win{
+			Id:          categorie.Id,
+			Description: categorie.Description,
+			IsWinner:    isWinner,
+		}
+
+		switch variantaExtrasa.Id {
+		case 1:
+			variantaJucata.WinsRegular = append(variantaJucata.WinsRegular, castig)
+		case 2:
+			variantaJucata.WinsSpecial = append(variantaJucata.WinsSpecial, castig)
+		}
+
+		foundWinner = foundWinner || isWinner
+	}
+}
+
+func (ruleGameChecker) CheckLuckyNumber(norocJucat *models.LuckyNumber, norocCastigator *models.LuckyNumber, game *models.Game) {
+	if norocJucat == nil || norocCastigator == nil {
+		return
+	}
+
+	castiguri := []models.Win{}
+	foundWinner := false
+
+	norocJucat.IsWinner = false
+
+	for _, categorie := range game.LuckyNumberCategories {
+		isWinner := false
+
+		if categorie.Offset != 0 {
+			isWinner = isLuckyNumberOffsetMatch(categorie, norocJucat.Value, norocCastigator.Value)
+		} else if !foundWinner {
+			isWinner = isLuckyNumberDigitsMatch(categorie, norocJucat.Value, norocCastigator.Value, game.LuckyNumberDigitCount)
+			foundWinner = isWinner
+		}
+
+		castiguri = append(castiguri, models.Win{
+			Id:          categorie.Id,
+			Description: categorie.Description,
+			IsWinner:    isWinner,
+		})
+
+		norocJucat.IsWinner = norocJucat.IsWinner || isWinner
+	}
+
+	norocJucat.Wins = castiguri
+}
+
+func (ruleGameChecker) DefaultCategories(game *models.Game) []models.Win {
+	return getDefaultCategoriiCastigVariante(game)
+}
+
+func isVariantCategoryMatch(categorie models.VariantCategoryRule, numereJucate []models.Number, numereExtrase []models.Number, secondaryMatchCount int) bool {
+	if secondaryMatchCount < categorie.SecondaryMatches {
+		return false
+	}
+
+	if categorie.FromFirst > 0 && categorie.FromFirst < len(numereExtrase) {
+		numereExtrase = numereExtrase[:categorie.FromFirst]
+	}
+
+	matchCount := 0
+	for _, numar := range numereExtrase {
+		if ContainsNumarByValue(numereJucate, numar) {
+			matchCount++
+		}
+	}
+
+	if categorie.OrMore {
+		return matchCount >= categorie.Matches
+	}
+
+	return matchCount == categorie.Matches
+}
+
+func isLuckyNumberDigitsMatch(categorie models.LuckyNumberCategoryRule, numarNorocJucat string, numarNorocCastigator string, norocLen int) bool {
+	n := categorie.Digits
+	if n <= 0 || n > norocLen || len(numarNorocJucat) != norocLen || len(numarNorocCastigator) != norocLen {
+		return false
+	}
+
+	if numarNorocJucat[norocLen-n:] == numarNorocCastigator[norocLen-n:] {
+		return true
+	}
+
+	return categorie.Match == models.LuckyNumberMatchFirstOrLast && numarNorocJucat[:n] == numarNorocCastigator[:n]
+}
+
+func isLuckyNumberOffsetMatch(categorie models.LuckyNumberCategoryRule, numarNorocJucat string, numarNorocCastigator string) bool {
+	numarNorocJucatInt, err1 := strconv.Atoi(numarNorocJucat)
+	numarNorocCastigatorInt, err2 := strconv.Atoi(numarNorocCastigator)
+
+	return err1 == nil && err2 == nil && numarNorocJucatInt == numarNorocCastigatorInt+categorie.Offset
+}
+
+// markWinningNumbers flags the played numbers that were drawn and returns how many there are.
+func markWinningNumbers(numereJucate []models.Number, numereExtrase []models.Number) int {
+	matchCount := 0
+	for i := range numereJucate {
+		if ContainsNumarByValue(numereExtrase, numereJucate[i]) {
+			numereJucate[i].IsWinner = true
+			matchCount++
+		}
+	}
+
+	return matchCount
+}
+
+func getDefaultCategoriiCastigVariante(game *models.Game) []models.Win {
+	castiguri := []models.Win{}
+	for _, categorie := range game.VariantCategories {
+		castiguri = append(castiguri, models.Win{
+			Id:          categorie.Id,
+			Description: categorie.Description,
+			IsWinner:    false,
+		})
+	}
+
+	return castiguri
+}
@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"fmt"
+	"loto-suite/backend/models"
+	"testing"
+)
+
+func TestRuleGameCheckerCheckVariant(t *testing.T) {
+	tests := []struct {
+		name     string
+		gameId   string
+		played   models.Variant
+		drawn    models.Variant
+		regular  []string
+		special  []string
+		noResult bool
+	}{
+		{
+			name:    "649 6/6",
+			gameId:  "649",
+			played:  models.Variant{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6)},
+			drawn:   models.Variant{Id: 1, Numbers: newTestNumbers(6, 5, 4, 3, 2, 1)},
+			regular: []string{"I (6/6)"},
+		},
+		{
+			name:    "649 4/6",
+			gameId:  "649",
+			played:  models.Variant{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6)},
+			drawn:   models.Variant{Id: 1, Numbers: newTestNumbers(1, 2, 3, 4, 40, 41)},
+			regular: []string{"III (4/6)"},
+		},
+		{
+			name:    "649 nothing",
+			gameId:  "649",
+			played:  models.Variant{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6)},
+			drawn:   models.Variant{Id: 1, Numbers: newTestNumbers(1, 2, 40, 41, 42, 43)},
+			regular: []string{},
+		},
+		{
+			name:    "540 5/6 from the first 5",
+			gameId:  "540",
+			played:  models.Variant{Numbers: newTestNumbers(1, 2, 3, 4, 5)},
+			drawn:   models.Variant{Id: 1, Numbers: newTestNumbers(1, 2, 3, 4, 5, 40)},
+			regular: []string{"I (5/6*)"},
+		},
+		{
+			name:    "540 5/6 with the last number",
+			gameId:  "540",
+			played:  models.Variant{Numbers: newTestNumbers(1, 2, 3, 4, 40)},
+			drawn:   models.Variant{Id: 1, Numbers: newTestNumbers(1, 2, 3, 4, 5, 40)},
+			regular: []string{"II (5/6)"},
+		},
+		{
+			name:    "joker I",
+			gameId:  "joker",
+			played:  models.Variant{Numbers: newTestNumbers(1, 2, 3, 4, 5), SecondaryNumbers: newTestNumbers(7)},
+			drawn:   models.Variant{Id: 1, Numbers: newTestNumbers(1, 2, 3, 4, 5), SecondaryNumbers: newTestNumbers(7)},
+			regular: []string{"I (5/5+J)"},
+		},
+		{
+			name:    "joker II",
+			gameId:  "joker",
+			played:  models.Variant{Numbers: newTestNumbers(1, 2, 3, 4, 5), SecondaryNumbers: newTestNumbers(7)},
+			drawn:   models.Variant{Id: 1, Numbers: newTestNumbers(1, 2, 3, 4, 5), SecondaryNumbers: newTestNumbers(8)},
+			regular: []string{"II (5/5)"},
+		},
+		{
+			name:    "joker VIII",
+			gameId:  "joker",
+			played:  models.Variant{Numbers: newTestNumbers(1, 2, 3, 4, 5), SecondaryNumbers: newTestNumbers(7)},
+			drawn:   models.Variant{Id: 1, Numbers: newTestNumbers(1, 40, 41, 42, 43), SecondaryNumbers: newTestNumbers(7)},
+			regular: []string{"VIII (1/5+J)"},
+		},
+		{
+			name:    "joker nothing",
+			gameId:  "joker",
+			played:  models.Variant{Numbers: newTestNumbers(1, 2, 3, 4, 5), SecondaryNumbers: newTestNumbers(7)},
+			drawn:   models.Variant{Id: 1, Numbers: newTestNumbers(1, 2, 41, 42, 43), SecondaryNumbers: newTestNumbers(8)},
+			regular: []string{},
+		},
+		{
+			name:    "special draw",
+			gameId:  "649",
+			played:  models.Variant{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6)},
+			drawn:   models.Variant{Id: 2, Numbers: newTestNumbers(1, 2, 3, 40, 41, 42)},
+			special: []string{"IV (3/6)"},
+		},
+		{
+			name:     "draw without results",
+			gameId:   "649",
+			played:   models.Variant{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6)},
+			drawn:    models.Variant{Id: 1},
+			regular:  []string{},
+			noResult: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := GetGameById(tt.gameId)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ruleGameChecker{}.CheckVariant(&tt.played, &tt.drawn, game)
+
+			if tt.drawn.Id == 1 {
+				if len(tt.played.WinsRegular) != len(game.VariantCategories) {
+					t.Errorf("expected %d categories, got %d", len(game.VariantCategories), len(tt.played.WinsRegular))
+				}
+
+				if winners := getWinnerIds(tt.played.WinsRegular); fmt.Sprint(winners) != fmt.Sprint(tt.regular) {
+					t.Errorf("expected winning categories %v, got %v", tt.regular, winners)
+				}
+			} else {
+				if len(tt.played.WinsRegular) != 0 {
+					t.Errorf("expected no regular categories, got %v", tt.played.WinsRegular)
+				}
+
+				if winners := getWinnerIds(tt.played.WinsSpecial); fmt.Sprint(winners) != fmt.Sprint(tt.special) {
+					t.Errorf("expected winning special categories %v, got %v", tt.special, winners)
+				}
+			}
+
+			if tt.noResult {
+				for _, numar := range tt.played.Numbers {
+					if numar.IsWinner {
+						t.Errorf("expected no winning numbers without results, got %d", numar.Value)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRuleGameCheckerCheckLuckyNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		gameId   string
+		played   string
+		drawn    string
+		expected []string
+	}{
+		{"649 I", "649", "1234567", "1234567", []string{"I"}},
+		{"649 V", "649", "9999567", "1234567", []string{"V"}},
+		{"649 first digits only", "649", "1234999", "1234567", []string{}},
+		{"649 N+3", "649", "1234570", "1234567", []string{"N+3"}},
+		{"649 N-3", "649", "1234564", "1234567", []string{"N-3"}},
+		{"joker I", "joker", "123456", "123456", []string{"I"}},
+		{"joker II from the first digits", "joker", "123459", "123456", []string{"II"}},
+		{"joker V from the last digits", "joker", "999956", "123456", []string{"V"}},
+		{"wrong length", "joker", "12345", "123456", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := GetGameById(tt.gameId)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			played := &models.LuckyNumber{Value: tt.played}
+			ruleGameChecker{}.CheckLuckyNumber(played, &models.LuckyNumber{Value: tt.drawn}, game)
+
+			if winners := getWinnerIds(played.Wins); fmt.Sprint(winners) != fmt.Sprint(tt.expected) {
+				t.Errorf("expected winning categories %v, got %v", tt.expected, winners)
+			}
+
+			if played.IsWinner != (len(tt.expected) > 0) {
+				t.Errorf("expected castigator %v, got %v", len(tt.expected) > 0, played.IsWinner)
+			}
+		})
+	}
+}
+
+func getWinnerIds(castiguri []models.Win) []string {
+	winners := []string{}
+	for _, castig := range castiguri {
+		if castig.IsWinner {
+			winners = append(winners, castig.Id)
+		}
+	}
+
+	return winners
+}
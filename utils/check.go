@@ -1,186 +1,254 @@
 package utils
 
 import (
-	"fmt"
+	"errors"
 	"loto-suite/backend/generics"
 	"loto-suite/backend/models"
-	"strconv"
 	"strings"
+	"time"
 )
 
+// ticketCheck holds a normalized CheckRequest together with the game rules it is scored by,
+// so the same ticket can be evaluated against any number of draws.
+type ticketCheck struct {
+	request models.CheckRequest
+	game    *models.Game
+	checker GameChecker
+	date    time.Time
+}
+
+func newTicketCheck(request models.CheckRequest) (*ticketCheck, error) {
+	if validation := ValidateTicket(request); !validation.IsValid {
+		return nil, &TicketValidationError{Errors: validation.Errors}
+	}
+
+	requestDate, _ := generics.TryParseDate(request.Date)
+
+	request.GameId = strings.ToLower(strings.TrimSpace(request.GameId))
+	game, err := GetGameById(request.GameId)
+	if err != nil {
+		return nil, err
+	}
+
+	checker, err := GetGameChecker(game.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	request.LuckyNumber = strings.TrimSpace(request.LuckyNumber)
+	request.Variants = normalizeVariants(request.Variants, game)
+
+	return &ticketCheck{
+		request: request,
+		game:    game,
+		checker: checker,
+		date:    requestDate,
+	}, nil
+}
+
 func CheckTicket(request models.CheckRequest) (*models.CheckResult, error) {
-	if len(request.Variants) == 0 {
-		return nil, fmt.Errorf("at least one set of numbers is required")
+	return checkTicket(request, newDrawResultsResolver())
+}
+
+// CheckTicketMultiDraw evaluates a ticket bought for several consecutive draws (see GetDrawCount),
+// starting with the draw on request.Date. Draws not held or not published yet are reported as pending;
+// any other failure to get the results, such as loto.ro being unreachable, is returned as an error.
+func CheckTicketMultiDraw(request models.CheckRequest) (*models.MultiDrawCheckResult, error) {
+	return checkTicketMultiDraw(request, newDrawResultsResolver())
+}
+
+// GetDrawCount returns the number of consecutive draws a ticket is played for: request.DrawCount,
+// or the draws between request.Date and request.DateTo when a range is given.
+func GetDrawCount(request models.CheckRequest) int {
+	if strings.TrimSpace(request.DateTo) != "" {
+		from, err1 := generics.TryParseDate(request.Date)
+		to, err2 := generics.TryParseDate(request.DateTo)
+		if err1 == nil && err2 == nil {
+			return max(countDrawDates(from, to), 1)
+		}
 	}
 
-	requestDate, dateParseErr := generics.TryParseDate(request.Date)
-	if dateParseErr != nil {
-		return nil, fmt.Errorf("invalid date format")
+	return max(request.DrawCount, 1)
+}
+
+func checkTicket(request models.CheckRequest, resolver *drawResultsResolver) (*models.CheckResult, error) {
+	ticket, err := newTicketCheck(request)
+	if err != nil {
+		return nil, err
 	}
 
-	month := strconv.Itoa(int(requestDate.Month()))
-	year := strconv.Itoa(requestDate.Year())
+	drawResult, err := resolver.find(ticket.game.Id, ticket.date)
+	if err != nil {
+		return nil, err
+	}
 
-	request.GameId = strings.ToLower(strings.TrimSpace(request.GameId))
-	drawResults, err := GetDrawResults(request.GameId, month, year)
+	return ticket.checkDraw(*drawResult), nil
+}
 
+func checkTicketMultiDraw(request models.CheckRequest, resolver *drawResultsResolver) (*models.MultiDrawCheckResult, error) {
+	ticket, err := newTicketCheck(request)
 	if err != nil {
-		fmt.Println(err)
-		return nil, fmt.Errorf("failed to get draw results: %v", err)
+		return nil, err
+	}
+
+	result := models.MultiDrawCheckResult{
+		Draws: []models.DrawCheckResult{},
 	}
 
-	drawResult, _ := generics.FindFirst(drawResults, func(dr models.DrawResult) bool {
-		date, err := generics.TryParseDate(dr.GameDate)
-		return err == nil && date.Equal(requestDate)
-	})
+	for _, drawDate := range GetNextDrawDates(ticket.date, GetDrawCount(request)) {
+		drawCheck := models.DrawCheckResult{
+			Date: drawDate.Format(generics.GoDateFormat),
+		}
+
+		var drawResult *models.DrawResult
+		err := ErrDrawNotFound
+		if !time.Now().Before(getDrawTime(drawDate)) {
+			drawResult, err = resolver.find(ticket.game.Id, drawDate)
+		}
+
+		if errors.Is(err, ErrDrawNotFound) {
+			drawCheck.Error = err.Error()
+			result.DrawsPending++
+		} else if err != nil {
+			return nil, err
+		} else {
+			drawCheck.Result = ticket.checkDraw(*drawResult)
+			result.DrawsChecked++
+			result.WinsTotal += drawCheck.Result.WinsTotal
+			result.TaxTotal += drawCheck.Result.TaxTotal
+			result.WinsTotalNet += drawCheck.Result.WinsTotalNet
+			result.WinsPendingCount += drawCheck.Result.WinsPendingCount
+			result.IsCastigator = result.IsCastigator || drawCheck.Result.IsCastigator
+		}
 
-	if drawResult.GameId == "" {
-		return nil, fmt.Errorf("no draw results found for the specified date")
+		result.Draws = append(result.Draws, drawCheck)
 	}
 
+	return &result, nil
+}
+
+func (t *ticketCheck) checkDraw(drawResult models.DrawResult) *models.CheckResult {
+	game := t.game
+	checker := t.checker
+
+	resolveDrawWinCategories(&drawResult, game)
+
 	checkResult := models.CheckResult{
 		DrawResult:     &drawResult,
-		VarianteJucate: request.Variants,
+		VarianteJucate: cloneVariants(t.request.Variants),
 	}
 
-	request.LuckyNumber = strings.TrimSpace(request.LuckyNumber)
-	if request.LuckyNumber != "" {
+	if t.request.LuckyNumber != "" {
 		checkResult.LuckyNumber = &models.LuckyNumber{
-			Value: request.LuckyNumber,
+			Value: t.request.LuckyNumber,
 		}
 	}
 
-	switch request.GameId {
-	case "649":
-		CheckBilet649(&checkResult)
-	case "540":
-		CheckBilet540(&checkResult)
-	case "joker":
-		CheckBiletJoker(&checkResult)
+	if checkResult.LuckyNumber != nil && checkResult.DrawResult.LuckyNumber != nil {
+		checker.CheckLuckyNumber(checkResult.LuckyNumber, checkResult.DrawResult.LuckyNumber, game)
+	}
+
+	checkVariants(checker, game, &checkResult)
+
+	for i := range checkResult.VarianteJucate {
+		if isSystematicVariant(checkResult.VarianteJucate[i], game) {
+			cumulateSystematicVariantWins(&checkResult.VarianteJucate[i], &drawResult)
+		}
 	}
 
-	checkResult.WinsTotal = 0
 	checkResult.WinsCumulatedVariantRegular = []models.WinCumulated{}
 	checkResult.WinsCumulatedVariantSpecial = []models.WinCumulated{}
 
 	for _, varianta := range checkResult.VarianteJucate {
-		for _, castig := range varianta.WinsRegular {
-			if castig.IsWinner {
-				castigIndex := generics.IndexOf(
-					checkResult.WinsCumulatedVariantRegular,
-					func(c models.WinCumulated) bool {
-						return c.Id == castig.Id
-					})
-
-				if castigIndex == -1 {
-					castigCumulat := models.WinCumulated{
-						Id:          castig.Id,
-						Description: castig.Description,
-						WinCount:    generics.Btoi(castig.IsWinner),
-						Amount:      0,
-					}
-
-					valoareCastig, found := generics.FindFirst(
-						drawResult.WinCategoriesVariantRegular,
-						func(v models.WinCategory) bool {
-							return v.Id == castig.Id
-						})
-
-					if found {
-						castigCumulat.Amount = valoareCastig.Amount
-					}
-
-					checkResult.WinsCumulatedVariantRegular = append(checkResult.WinsCumulatedVariantRegular, castigCumulat)
-				} else {
-					checkResult.WinsCumulatedVariantRegular[castigIndex].WinCount += generics.Btoi(castig.IsWinner)
-				}
-			}
-		}
-
-		for _, castig := range varianta.WinsSpecial {
-			if castig.IsWinner {
-				castigIndex := generics.IndexOf(
-					checkResult.WinsCumulatedVariantSpecial,
-					func(c models.WinCumulated) bool {
-						return c.Id == castig.Id
-					})
-
-				if castigIndex == -1 {
-					castigCumulat := models.WinCumulated{
-						Id:          castig.Id,
-						Description: castig.Description,
-						WinCount:    generics.Btoi(castig.IsWinner),
-						Amount:      0,
-					}
-
-					valoareCastig, found := generics.FindFirst(
-						drawResult.WinCategoriesVariantSpecial,
-						func(v models.WinCategory) bool {
-							return v.Id == castig.Id
-						})
-
-					if found {
-						castigCumulat.Amount = valoareCastig.Amount
-					}
-
-					checkResult.WinsCumulatedVariantSpecial = append(checkResult.WinsCumulatedVariantSpecial, castigCumulat)
-				} else {
-					checkResult.WinsCumulatedVariantSpecial[castigIndex].WinCount += generics.Btoi(castig.IsWinner)
-				}
-			}
-		}
+		checkResult.WinsCumulatedVariantRegular = cumulateWins(checkResult.WinsCumulatedVariantRegular, varianta.WinsRegular, drawResult.WinCategoriesVariantRegular)
+		checkResult.WinsCumulatedVariantSpecial = cumulateWins(checkResult.WinsCumulatedVariantSpecial, varianta.WinsSpecial, drawResult.WinCategoriesVariantSpecial)
 	}
 
-	for _, castigVarianta := range checkResult.WinsCumulatedVariantRegular {
-		checkResult.WinsTotal += float64(castigVarianta.WinCount) * castigVarianta.Amount
+	checkResult.WinsCumulatedLuckyNumber = []models.WinCumulated{}
+	if checkResult.LuckyNumber != nil {
+		checkResult.WinsCumulatedLuckyNumber = cumulateWins(checkResult.WinsCumulatedLuckyNumber, checkResult.LuckyNumber.Wins, drawResult.WinCategoriesLuckyNumber)
 	}
 
-	for _, castigVarianta := range checkResult.WinsCumulatedVariantSpecial {
-		checkResult.WinsTotal += float64(castigVarianta.WinCount) * castigVarianta.Amount
+	checkResult.WinsTotal = sumWins(checkResult.WinsCumulatedVariantRegular) +
+		sumWins(checkResult.WinsCumulatedVariantSpecial) +
+		sumWins(checkResult.WinsCumulatedLuckyNumber)
+
+	// A matched category is a win even when its amount is not known yet
+	checkResult.IsCastigator = len(checkResult.WinsCumulatedVariantRegular) > 0 ||
+		len(checkResult.WinsCumulatedVariantSpecial) > 0 ||
+		len(checkResult.WinsCumulatedLuckyNumber) > 0
+
+	checkResult.WinsPendingCount = countPendingWins(checkResult.WinsCumulatedVariantRegular) +
+		countPendingWins(checkResult.WinsCumulatedVariantSpecial) +
+		countPendingWins(checkResult.WinsCumulatedLuckyNumber)
+
+	applyTaxes(&checkResult)
+
+	return &checkResult
+}
+
+// cloneVariants copies the played numbers so that each draw check marks its own winners.
+func cloneVariants(variante []models.Variant) []models.Variant {
+	clones := make([]models.Variant, 0, len(variante))
+	for _, varianta := range variante {
+		clones = append(clones, models.Variant{
+			Id:               varianta.Id,
+			Numbers:          append([]models.Number{}, varianta.Numbers...),
+			SecondaryNumbers: append([]models.Number{}, varianta.SecondaryNumbers...),
+			IsSystematic:     varianta.IsSystematic,
+		})
 	}
 
-	checkResult.WinsCumulatedLuckyNumber = []models.WinCumulated{}
-	for _, castig := range checkResult.LuckyNumber.Wins {
-		if castig.IsWinner {
-			castigIndex := generics.IndexOf(
-				checkResult.WinsCumulatedLuckyNumber,
-				func(c models.WinCumulated) bool {
-					return c.Id == castig.Id
-				})
-
-			if castigIndex == -1 {
-				castigCumulat := models.WinCumulated{
-					Id:          castig.Id,
-					Description: castig.Description,
-					WinCount:    generics.Btoi(castig.IsWinner),
-					Amount:      0,
-				}
-
-				valoareCastig, found := generics.FindFirst(
-					drawResult.WinCategoriesLuckyNumber,
-					func(v models.WinCategory) bool {
-						return v.Id == castig.Id
-					})
-
-				if found {
-					castigCumulat.Amount = valoareCastig.Amount
-				}
-
-				checkResult.WinsCumulatedLuckyNumber = append(checkResult.WinsCumulatedLuckyNumber, castigCumulat)
-			} else {
-				checkResult.WinsCumulatedLuckyNumber[castigIndex].WinCount += generics.Btoi(castig.IsWinner)
-			}
+	return clones
+}
+
+func cumulateWins(castiguriCumulate []models.WinCumulated, castiguri []models.Win, categoriiCastig []models.WinCategory) []models.WinCumulated {
+	for _, castig := range castiguri {
+		if !castig.IsWinner {
+			continue
 		}
-	}
 
-	for _, castigNoroc := range checkResult.WinsCumulatedLuckyNumber {
-		checkResult.WinsTotal += float64(castigNoroc.WinCount) * castigNoroc.Amount
+		castigIndex := generics.IndexOf(
+			castiguriCumulate,
+			func(c models.WinCumulated) bool {
+				return c.Id == castig.Id
+			})
+
+		if castigIndex != -1 {
+			castiguriCumulate[castigIndex].WinCount++
+			continue
+		}
+
+		castigCumulat := models.WinCumulated{
+			Id:          castig.Id,
+			Description: castig.Description,
+			WinCount:    1,
+			Amount:      0,
+			State:       models.PrizeStatePending,
+		}
+
+		valoareCastig, found := generics.FindFirst(
+			categoriiCastig,
+			func(v models.WinCategory) bool {
+				return v.Id == castig.Id
+			})
+
+		if found {
+			castigCumulat.Amount = valoareCastig.Amount
+			castigCumulat.State = valoareCastig.State
+		}
+
+		castiguriCumulate = append(castiguriCumulate, castigCumulat)
 	}
 
-	checkResult.IsCastigator = checkResult.WinsTotal > 0
+	return castiguriCumulate
+}
 
-	// v, _ := json.Marshal(checkResult)
-	// fmt.Println(string(v))
+func sumWins(castiguriCumulate []models.WinCumulated) float64 {
+	total := 0.0
+	for _, castig := range castiguriCumulate {
+		total += float64(castig.WinCount) * castig.Amount
+	}
 
-	return &checkResult, nil
+	return total
 }
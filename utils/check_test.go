@@ -0,0 +1,217 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"loto-suite/backend/models"
+	"testing"
+)
+
+// fakeDrawSource serves the draws of each month from memory, keyed by "<game id>_<month>_<year>".
+// It is not the scraper, so the results it returns never go through the cache or the store.
+type fakeDrawSource struct {
+	months map[string][]models.DrawResult
+	errs   map[string]error
+	calls  map[string]int
+}
+
+func newFakeDrawSource(drawResults ...models.DrawResult) *fakeDrawSource {
+	source := &fakeDrawSource{
+		months: map[string][]models.DrawResult{},
+		errs:   map[string]error{},
+		calls:  map[string]int{},
+	}
+
+	for _, drawResult := range drawResults {
+		var year, month, day int
+		fmt.Sscanf(drawResult.GameDate, "%d-%d-%d", &year, &month, &day)
+		key := fmt.Sprintf("%s_%d_%d", drawResult.GameId, month, year)
+		source.months[key] = append(source.months[key], drawResult)
+	}
+
+	return source
+}
+
+func (s *fakeDrawSource) Name() string {
+	return "fake"
+}
+
+func (s *fakeDrawSource) GetDrawResults(game *models.Game, month string, year string) ([]models.DrawResult, error) {
+	key := fmt.Sprintf("%s_%s_%s", game.Id, month, year)
+	s.calls[key]++
+
+	if err := s.errs[key]; err != nil {
+		return nil, err
+	}
+
+	return append([]models.DrawResult{}, s.months[key]...), nil
+}
+
+// useDrawSource makes source the draw source for the rest of the test.
+func useDrawSource(t *testing.T, source DrawSource) {
+	t.Helper()
+
+	previous := GetDrawSource()
+	SetDrawSource(source)
+	t.Cleanup(func() { SetDrawSource(previous) })
+}
+
+// newTestDraw returns a published 649 draw where every prize category was won.
+func newTestDraw(date string, numbers ...int) models.DrawResult {
+	drawResult := models.DrawResult{
+		GameId:          "649",
+		GameDate:        date,
+		VariantRegular:  &models.Variant{Id: 1, Numbers: newTestNumbers(numbers...)},
+		LuckyNumber:     &models.LuckyNumber{Value: "0482915"},
+		LuckyNumberName: "NOROC",
+		WinCategoriesVariantRegular: []models.WinCategory{
+			{Id: "I (6/6)", WinnersCount: 1, Amount: 1000000},
+			{Id: "II (5/6)", WinnersCount: 4, Amount: 10000},
+			{Id: "III (4/6)", WinnersCount: 200, Amount: 300},
+			{Id: "IV (3/6)", WinnersCount: 5000, Amount: 30},
+		},
+		WinCategoriesLuckyNumber: []models.WinCategory{
+			{Id: "I", WinnersCount: 1, Amount: 200000},
+		},
+	}
+
+	completeDrawResult(&drawResult)
+
+	return drawResult
+}
+
+func newTestNumbers(values ...int) []models.Number {
+	numere := []models.Number{}
+	for _, value := range values {
+		numere = append(numere, models.Number{Value: value})
+	}
+
+	return numere
+}
+
+func newTestRequest(date string, numbers ...int) models.CheckRequest {
+	return models.CheckRequest{
+		GameId:   "649",
+		Date:     date,
+		Variants: []models.Variant{{Id: 1, Numbers: newTestNumbers(numbers...)}},
+	}
+}
+
+func TestGetDrawCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		drawCount int
+		date      string
+		dateTo    string
+		expected  int
+	}{
+		{"single draw by default", 0, "2024-03-28", "", 1},
+		{"draw count", 3, "2024-03-28", "", 3},
+		{"range across months", 0, "2024-03-28", "2024-04-07", 4},
+		{"range of one day", 0, "2024-03-31", "2024-03-31", 1},
+		{"invalid end date falls back to the draw count", 2, "2024-03-28", "not a date", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := models.CheckRequest{Date: tt.date, DateTo: tt.dateTo, DrawCount: tt.drawCount}
+			if count := GetDrawCount(request); count != tt.expected {
+				t.Errorf("expected %d draws, got %d", tt.expected, count)
+			}
+		})
+	}
+}
+
+func TestCheckTicketMultiDraw(t *testing.T) {
+	errUpstream := fmt.Errorf("%w: www.loto.ro", ErrCircuitOpen)
+
+	tests := []struct {
+		name      string
+		request   models.CheckRequest
+		source    *fakeDrawSource
+		checked   []string
+		pending   []string
+		winsTotal float64
+		err       error
+	}{
+		{
+			name:      "range across a month boundary",
+			request:   models.CheckRequest{GameId: "649", Date: "2024-03-28", DateTo: "2024-04-04", Variants: newTestRequest("", 1, 2, 3, 4, 5, 6).Variants},
+			source:    newFakeDrawSource(newTestDraw("2024-03-28", 1, 2, 3, 10, 11, 12), newTestDraw("2024-03-31", 20, 21, 22, 23, 24, 25), newTestDraw("2024-04-04", 1, 2, 3, 4, 11, 12)),
+			checked:   []string{"2024-03-28", "2024-03-31", "2024-04-04"},
+			pending:   []string{},
+			winsTotal: 330,
+		},
+		{
+			name:      "draw not published yet",
+			request:   models.CheckRequest{GameId: "649", Date: "2024-03-28", DrawCount: 2, Variants: newTestRequest("", 1, 2, 3, 4, 5, 6).Variants},
+			source:    newFakeDrawSource(newTestDraw("2024-03-28", 1, 2, 3, 10, 11, 12)),
+			checked:   []string{"2024-03-28"},
+			pending:   []string{"2024-03-31"},
+			winsTotal: 30,
+		},
+		{
+			name:    "draws in the future are not fetched",
+			request: models.CheckRequest{GameId: "649", Date: "2099-01-01", DrawCount: 2, Variants: newTestRequest("", 1, 2, 3, 4, 5, 6).Variants},
+			source:  newFakeDrawSource(),
+			checked: []string{},
+			pending: []string{"2099-01-01", "2099-01-04"},
+		},
+		{
+			name:    "upstream failure is an error",
+			request: models.CheckRequest{GameId: "649", Date: "2024-03-28", DrawCount: 2, Variants: newTestRequest("", 1, 2, 3, 4, 5, 6).Variants},
+			source: func() *fakeDrawSource {
+				source := newFakeDrawSource()
+				source.errs["649_3_2024"] = errUpstream
+				return source
+			}(),
+			err: ErrCircuitOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useDrawSource(t, tt.source)
+
+			result, err := CheckTicketMultiDraw(tt.request)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("expected %v, got %v", tt.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checked, pending := []string{}, []string{}
+			for _, draw := range result.Draws {
+				if draw.Result != nil {
+					checked = append(checked, draw.Date)
+				} else {
+					pending = append(pending, draw.Date)
+				}
+			}
+
+			if fmt.Sprint(checked) != fmt.Sprint(tt.checked) || fmt.Sprint(pending) != fmt.Sprint(tt.pending) {
+				t.Errorf("expected checked %v and pending %v, got %v and %v", tt.checked, tt.pending, checked, pending)
+			}
+
+			if result.DrawsChecked != len(tt.checked) || result.DrawsPending != len(tt.pending) {
+				t.Errorf("expected %d checked and %d pending, got %d and %d", len(tt.checked), len(tt.pending), result.DrawsChecked, result.DrawsPending)
+			}
+
+			if result.WinsTotal != tt.winsTotal {
+				t.Errorf("expected wins total %v, got %v", tt.winsTotal, result.WinsTotal)
+			}
+
+			for key, calls := range tt.source.calls {
+				if calls > 1 {
+					t.Errorf("month %s fetched %d times instead of once", key, calls)
+				}
+			}
+		})
+	}
+}
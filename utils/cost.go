@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"fmt"
+	"loto-suite/backend/models"
+	"strings"
+)
+
+func GetGamePrice(gameId string) (*models.GamePrice, error) {
+	game, err := GetGameById(gameId)
+	if err != nil {
+		return nil, err
+	}
+
+	if game.Price.VariantPrice <= 0 {
+		return nil, fmt.Errorf("no prices defined for game: %s", gameId)
+	}
+
+	return &game.Price, nil
+}
+
+// CalculateTicketCost prices a ticket: every line of every variant (all the combinations of a
+// systematic one), the lucky number and the special draw option, for each draw played.
+func CalculateTicketCost(request models.CheckRequest) (*models.TicketCost, error) {
+	if validation := validateTicket(request, false); !validation.IsValid {
+		return nil, &TicketValidationError{Errors: validation.Errors}
+	}
+
+	game, err := GetGameById(strings.ToLower(strings.TrimSpace(request.GameId)))
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := GetGamePrice(game.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	cost := models.TicketCost{
+		GameId:   game.Id,
+		Currency: price.Currency,
+		Draws:    GetDrawCount(request),
+	}
+
+	for _, varianta := range normalizeVariants(request.Variants, game) {
+		cost.Lines += getVariantLinesCount(varianta, game)
+	}
+
+	cost.VariantsCost = float64(cost.Lines) * price.VariantPrice
+
+	if strings.TrimSpace(request.LuckyNumber) != "" {
+		cost.LuckyNumberCost = price.LuckyNumberPrice
+	}
+
+	if request.SpecialDraw {
+		cost.SpecialDrawCost = float64(cost.Lines) * price.SpecialDrawVariantPrice
+	}
+
+	cost.CostPerDraw = cost.VariantsCost + cost.LuckyNumberCost + cost.SpecialDrawCost
+	cost.Total = cost.CostPerDraw * float64(cost.Draws)
+
+	return &cost, nil
+}
+
+// getVariantLinesCount returns how many simple lines a variant stands for: one for a simple
+// variant, C(n, k) for a systematic one, where only the main numbers are combined.
+func getVariantLinesCount(varianta models.Variant, game *models.Game) int {
+	if !isSystematicVariant(varianta, game) {
+		return 1
+	}
+
+	return max(CombinationsCount(len(varianta.Numbers), game.VariantMinNumbersCount), 1)
+}
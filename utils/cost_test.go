@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"errors"
+	"loto-suite/backend/models"
+	"testing"
+)
+
+func TestCalculateTicketCost(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     models.CheckRequest
+		lines       int
+		draws       int
+		costPerDraw float64
+		total       float64
+	}{
+		{
+			name:        "simple variant",
+			request:     newTestRequest("2024-03-28", 1, 2, 3, 4, 5, 6),
+			lines:       1,
+			draws:       1,
+			costPerDraw: 7,
+			total:       7,
+		},
+		{
+			name: "variants and lucky number",
+			request: models.CheckRequest{GameId: "649", LuckyNumber: "1234567", Variants: []models.Variant{
+				{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6)},
+				{Numbers: newTestNumbers(7, 8, 9, 10, 11, 12)},
+			}},
+			lines:       2,
+			draws:       1,
+			costPerDraw: 24,
+			total:       24,
+		},
+		{
+			name: "system of 8 numbers",
+			request: models.CheckRequest{GameId: "649", Variants: []models.Variant{
+				{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6, 7, 8), IsSystematic: true},
+			}},
+			lines:       28,
+			draws:       1,
+			costPerDraw: 196,
+			total:       196,
+		},
+		{
+			name:        "consecutive draws",
+			request:     models.CheckRequest{GameId: "649", DrawCount: 3, Variants: newTestRequest("", 1, 2, 3, 4, 5, 6).Variants},
+			lines:       1,
+			draws:       3,
+			costPerDraw: 7,
+			total:       21,
+		},
+		{
+			name:        "date range",
+			request:     models.CheckRequest{GameId: "649", Date: "2024-03-28", DateTo: "2024-04-07", Variants: newTestRequest("", 1, 2, 3, 4, 5, 6).Variants},
+			lines:       1,
+			draws:       4,
+			costPerDraw: 7,
+			total:       28,
+		},
+		{
+			name: "joker system combines only the main numbers",
+			request: models.CheckRequest{GameId: "joker", Variants: []models.Variant{
+				{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6, 7), SecondaryNumbers: newTestNumbers(9), IsSystematic: true},
+			}},
+			lines:       21,
+			draws:       1,
+			costPerDraw: 168,
+			total:       168,
+		},
+		{
+			name:        "lucky number alone",
+			request:     models.CheckRequest{GameId: "540", LuckyNumber: "123456"},
+			lines:       0,
+			draws:       1,
+			costPerDraw: 5,
+			total:       5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cost, err := CalculateTicketCost(tt.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cost.Lines != tt.lines || cost.Draws != tt.draws {
+				t.Errorf("expected %d lines for %d draws, got %d and %d", tt.lines, tt.draws, cost.Lines, cost.Draws)
+			}
+
+			if cost.CostPerDraw != tt.costPerDraw || cost.Total != tt.total {
+				t.Errorf("expected %v per draw and %v in total, got %v and %v", tt.costPerDraw, tt.total, cost.CostPerDraw, cost.Total)
+			}
+
+			if cost.Currency != "RON" {
+				t.Errorf("expected the cost in RON, got %s", cost.Currency)
+			}
+		})
+	}
+}
+
+func TestCalculateTicketCostInvalid(t *testing.T) {
+	_, err := CalculateTicketCost(newTestRequest("", 1, 2, 3))
+
+	var validationErr *TicketValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+}
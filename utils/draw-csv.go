@@ -0,0 +1,247 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"loto-suite/backend/generics"
+	"loto-suite/backend/models"
+	"strconv"
+	"strings"
+)
+
+// drawResultsCsvHeader is the first row of a CSV file with draw results, one draw per row.
+// Numbers are separated by spaces and the prize categories by ";", each as "id|winners|amount|report".
+var drawResultsCsvHeader = []string{
+	"game_id",
+	"date",
+	"numere",
+	"numere_secundare",
+	"numere_speciale",
+	"numere_secundare_speciale",
+	"noroc",
+	"categorii_varianta",
+	"categorii_varianta_speciala",
+	"categorii_noroc",
+}
+
+// WriteDrawResultsCsv writes the draws to w in the CSV format read by ReadDrawResultsCsv.
+func WriteDrawResultsCsv(w io.Writer, drawResults []models.DrawResult) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(drawResultsCsvHeader); err != nil {
+		return err
+	}
+
+	for _, drawResult := range drawResults {
+		row := []string{
+			drawResult.GameId,
+			drawResult.GameDate,
+			"", "", "", "", "",
+			formatCsvWinCategories(drawResult.WinCategoriesVariantRegular),
+			formatCsvWinCategories(drawResult.WinCategoriesVariantSpecial),
+			formatCsvWinCategories(drawResult.WinCategoriesLuckyNumber),
+		}
+
+		if drawResult.VariantRegular != nil {
+			row[2] = formatCsvNumbers(drawResult.VariantRegular.Numbers)
+			row[3] = formatCsvNumbers(drawResult.VariantRegular.SecondaryNumbers)
+		}
+
+		if drawResult.VariantSpecial != nil {
+			row[4] = formatCsvNumbers(drawResult.VariantSpecial.Numbers)
+			row[5] = formatCsvNumbers(drawResult.VariantSpecial.SecondaryNumbers)
+		}
+
+		if drawResult.LuckyNumber != nil {
+			row[6] = drawResult.LuckyNumber.Value
+		}
+
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// ReadDrawResultsCsv reads the draws written by WriteDrawResultsCsv. The prize states and the
+// jackpots are derived from the prize categories, as for scraped draws.
+func ReadDrawResultsCsv(r io.Reader) ([]models.DrawResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(drawResultsCsvHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return []models.DrawResult{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	for i, column := range drawResultsCsvHeader {
+		if strings.TrimSpace(header[i]) != column {
+			return nil, fmt.Errorf("unexpected CSV column %q, expected %q", header[i], column)
+		}
+	}
+
+	drawResults := []models.DrawResult{}
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		drawResult, err := parseCsvDrawResult(row)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		drawResults = append(drawResults, drawResult)
+	}
+
+	return drawResults, nil
+}
+
+func parseCsvDrawResult(row []string) (models.DrawResult, error) {
+	game, err := GetGameById(strings.ToLower(strings.TrimSpace(row[0])))
+	if err != nil {
+		return models.DrawResult{}, err
+	}
+
+	date, err := generics.TryParseDate(strings.TrimSpace(row[1]))
+	if err != nil {
+		return models.DrawResult{}, fmt.Errorf("invalid date %q", row[1])
+	}
+
+	drawResult := models.DrawResult{
+		GameId:          game.Id,
+		GameDate:        date.Format(generics.GoDateFormat),
+		LuckyNumberName: game.LuckyNumberName,
+		LuckyNumber: &models.LuckyNumber{
+			Value: strings.TrimSpace(row[6]),
+		},
+	}
+
+	if drawResult.VariantRegular, err = parseCsvVariant(1, row[2], row[3]); err != nil {
+		return models.DrawResult{}, err
+	}
+
+	if strings.TrimSpace(row[4]) != "" {
+		if drawResult.VariantSpecial, err = parseCsvVariant(2, row[4], row[5]); err != nil {
+			return models.DrawResult{}, err
+		}
+	}
+
+	if drawResult.WinCategoriesVariantRegular, err = parseCsvWinCategories(row[7]); err != nil {
+		return models.DrawResult{}, err
+	}
+
+	if drawResult.WinCategoriesVariantSpecial, err = parseCsvWinCategories(row[8]); err != nil {
+		return models.DrawResult{}, err
+	}
+
+	if drawResult.WinCategoriesLuckyNumber, err = parseCsvWinCategories(row[9]); err != nil {
+		return models.DrawResult{}, err
+	}
+
+	normalizeDrawResult(&drawResult, game)
+	completeDrawResult(&drawResult)
+
+	return drawResult, nil
+}
+
+func parseCsvVariant(id int, numbers string, secondaryNumbers string) (*models.Variant, error) {
+	numere, err := parseCsvNumbers(numbers)
+	if err != nil {
+		return nil, err
+	}
+
+	numereSecundare, err := parseCsvNumbers(secondaryNumbers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Variant{
+		Id:               id,
+		Numbers:          numere,
+		SecondaryNumbers: numereSecundare,
+	}, nil
+}
+
+func parseCsvNumbers(value string) ([]models.Number, error) {
+	numere := []models.Number{}
+	for _, field := range strings.Fields(value) {
+		numar, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", field)
+		}
+
+		numere = append(numere, models.Number{Value: numar})
+	}
+
+	return numere, nil
+}
+
+func formatCsvNumbers(numere []models.Number) string {
+	values := make([]string, 0, len(numere))
+	for _, numar := range numere {
+		values = append(values, strconv.Itoa(numar.Value))
+	}
+
+	return strings.Join(values, " ")
+}
+
+func parseCsvWinCategories(value string) ([]models.WinCategory, error) {
+	categoriiCastig := []models.WinCategory{}
+	for _, field := range strings.Split(value, ";") {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+
+		parts := strings.Split(field, "|")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("invalid prize category %q, expected id|winners|amount|report", field)
+		}
+
+		castigatori, err1 := strconv.Atoi(strings.TrimSpace(parts[1]))
+		suma, err2 := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
+		report, err3 := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
+		if err := errors.Join(err1, err2, err3); err != nil {
+			return nil, fmt.Errorf("invalid prize category %q: %w", field, err)
+		}
+
+		categoriiCastig = append(categoriiCastig, models.WinCategory{
+			Id:           strings.TrimSpace(parts[0]),
+			WinnersCount: castigatori,
+			Amount:       suma,
+			Report:       report,
+		})
+	}
+
+	return categoriiCastig, nil
+}
+
+func formatCsvWinCategories(categoriiCastig []models.WinCategory) string {
+	fields := make([]string, 0, len(categoriiCastig))
+	for _, categorie := range categoriiCastig {
+		fields = append(fields, fmt.Sprintf("%s|%d|%s|%s",
+			categorie.Id,
+			categorie.WinnersCount,
+			strconv.FormatFloat(categorie.Amount, 'f', -1, 64),
+			strconv.FormatFloat(categorie.Report, 'f', -1, 64)))
+	}
+
+	return strings.Join(fields, ";")
+}
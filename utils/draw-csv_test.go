@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"loto-suite/backend/models"
+	"strings"
+	"testing"
+)
+
+// testDrawResultsCsvHeader is the header row written by WriteDrawResultsCsv.
+const testDrawResultsCsvHeader = "game_id,date,numere,numere_secundare,numere_speciale,numere_secundare_speciale,noroc,categorii_varianta,categorii_varianta_speciala,categorii_noroc\n"
+
+func TestDrawResultsCsvRoundTrip(t *testing.T) {
+	drawWithSpecial := newTestDraw("2024-03-31", 1, 2, 3, 4, 5, 6)
+	drawWithSpecial.VariantSpecial = &models.Variant{Id: 2, Numbers: newTestNumbers(7, 8, 9, 10, 11, 12)}
+	drawWithSpecial.WinCategoriesVariantSpecial = []models.WinCategory{
+		{Id: "I (6/6)", WinnersCount: 0, Amount: 0, Report: 250000.5},
+		{Id: "II (5/6)", WinnersCount: 2, Amount: 5000.25},
+	}
+	completeDrawResult(&drawWithSpecial)
+
+	jokerDraw := models.DrawResult{
+		GameId:   "joker",
+		GameDate: "2024-03-28",
+		VariantRegular: &models.Variant{
+			Id:               1,
+			Numbers:          newTestNumbers(7, 14, 23, 35, 41),
+			SecondaryNumbers: newTestNumbers(9),
+		},
+		LuckyNumber:     &models.LuckyNumber{Value: "305617"},
+		LuckyNumberName: "NOROC PLUS",
+		WinCategoriesVariantRegular: []models.WinCategory{
+			{Id: "I (5/5+J)", WinnersCount: 0, Amount: 0, Report: 4502880},
+			{Id: "II (5/5)", WinnersCount: 1, Amount: 61200},
+		},
+	}
+	completeDrawResult(&jokerDraw)
+
+	tests := []struct {
+		name        string
+		drawResults []models.DrawResult
+	}{
+		{"no draws", []models.DrawResult{}},
+		{"draw with all prizes paid", []models.DrawResult{newTestDraw("2024-03-28", 1, 2, 3, 4, 5, 6)}},
+		{"draw with a special draw and a rolled over jackpot", []models.DrawResult{drawWithSpecial}},
+		{"draw with a secondary number", []models.DrawResult{jokerDraw}},
+		{"draws of several games", []models.DrawResult{jokerDraw, drawWithSpecial}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			if err := WriteDrawResultsCsv(&buffer, tt.drawResults); err != nil {
+				t.Fatal(err)
+			}
+
+			drawResults, err := ReadDrawResultsCsv(&buffer)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected, _ := json.Marshal(tt.drawResults)
+			actual, _ := json.Marshal(drawResults)
+			if !bytes.Equal(expected, actual) {
+				t.Errorf("expected %s\ngot      %s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestReadDrawResultsCsv(t *testing.T) {
+	tests := []struct {
+		name     string
+		csv      string
+		expected []string
+	}{
+		{"empty file", "", []string{}},
+		{"header only", testDrawResultsCsvHeader, []string{}},
+		{"normalizes the game and the date", testDrawResultsCsvHeader + "JOKER,28.03.2024,7 14 23 35 41,9,,,305617,,,\n", []string{"joker 2024-03-28"}},
+		{"legacy joker as the last number", testDrawResultsCsvHeader + "joker,2024-03-28,7 14 23 35 41 9,,,,305617,,,\n", []string{"joker 2024-03-28"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drawResults, err := ReadDrawResultsCsv(strings.NewReader(tt.csv))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual := []string{}
+			for _, drawResult := range drawResults {
+				actual = append(actual, drawResult.GameId+" "+drawResult.GameDate)
+
+				if drawResult.GameId == "joker" && len(drawResult.VariantRegular.SecondaryNumbers) != 1 {
+					t.Errorf("expected the joker as a secondary number, got %v", drawResult.VariantRegular)
+				}
+			}
+
+			if strings.Join(actual, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected draws %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestReadDrawResultsCsvErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		csv  string
+		err  string
+	}{
+		{"unexpected column", strings.Replace(testDrawResultsCsvHeader, "noroc,", "lucky,", 1), `unexpected CSV column "lucky"`},
+		{"missing column", "game_id,date,numere\n", "wrong number of fields"},
+		{"missing field in a row", testDrawResultsCsvHeader + "649,2024-03-28,1 2 3 4 5 6\n", "wrong number of fields"},
+		{"unknown game", testDrawResultsCsvHeader + "keno,2024-03-28,1 2 3 4 5 6,,,,0482915,,,\n", "line 2: unsupported game: keno"},
+		{"invalid date", testDrawResultsCsvHeader + "649,2024-13-45,1 2 3 4 5 6,,,,0482915,,,\n", `invalid date "2024-13-45"`},
+		{"invalid number", testDrawResultsCsvHeader + "649,2024-03-28,1 2 x 4 5 6,,,,0482915,,,\n", `invalid number "x"`},
+		{"invalid special number", testDrawResultsCsvHeader + "649,2024-03-28,1 2 3 4 5 6,,7 8 y,,0482915,,,\n", `invalid number "y"`},
+		{"prize category without a report", testDrawResultsCsvHeader + "649,2024-03-28,1 2 3 4 5 6,,,,0482915,I (6/6)|1|1000000,,\n", "expected id|winners|amount|report"},
+		{"invalid winners count", testDrawResultsCsvHeader + "649,2024-03-28,1 2 3 4 5 6,,,,0482915,I (6/6)|one|1000000|0,,\n", `invalid prize category "I (6/6)|one|1000000|0"`},
+		{"invalid amount", testDrawResultsCsvHeader + "649,2024-03-28,1 2 3 4 5 6,,,,0482915,,,I|1|lots|0\n", `invalid prize category "I|1|lots|0"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadDrawResultsCsv(strings.NewReader(tt.csv))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
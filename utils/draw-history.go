@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"loto-suite/backend/generics"
+	"loto-suite/backend/models"
+	"loto-suite/backend/store"
+	"strconv"
+	"time"
+)
+
+var ErrDrawNotFound = errors.New("no draw results found for the specified date")
+
+// maxLatestDraws caps the number of draws returned by GetLatestDrawResults.
+const maxLatestDraws = 500
+
+// maxLatestSourceMonths bounds the months read from a draw source for a game without a known first draw.
+const maxLatestSourceMonths = 120
+
+// GetDrawResult returns the draw of a game on the given date from the results of its month.
+// The store is only read for closed months marked complete, so a draw stored while its prizes
+// were still pending is refreshed like the rest of its month.
+func GetDrawResult(gameId string, date time.Time) (*models.DrawResult, error) {
+	game, err := GetGameById(gameId)
+	if err != nil {
+		return nil, err
+	}
+
+	drawResults, err := GetDrawResults(game.Id, strconv.Itoa(int(date.Month())), strconv.Itoa(date.Year()))
+	if err != nil {
+		return nil, err
+	}
+
+	drawResult, found := generics.FindFirst(drawResults, func(dr models.DrawResult) bool {
+		drawDate, err := generics.TryParseDate(dr.GameDate)
+		return err == nil && drawDate.Equal(date)
+	})
+
+	if !found {
+		return nil, ErrDrawNotFound
+	}
+
+	return &drawResult, nil
+}
+
+// GetDrawHistory returns the stored draws of a game between from and to (inclusive), oldest first.
+// It never fetches from the scraper: months missing from the store have to be backfilled first.
+// Fixtures and imports are not stored, so their draws are read from the source month by month.
+func GetDrawHistory(gameId string, from time.Time, to time.Time) ([]models.DrawResult, error) {
+	game, err := GetGameById(gameId)
+	if err != nil {
+		return nil, err
+	}
+
+	if to.Before(from) {
+		return nil, fmt.Errorf("the end date is before the start date")
+	}
+
+	if !IsDrawSourceStored() {
+		return getSourceDrawHistory(game, from, to)
+	}
+
+	drawResults, err := store.GetDrawsBetween(game.Id, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizeDrawResults(drawResults, game)
+
+	return drawResults, nil
+}
+
+// GetLatestDrawResults returns the last count stored draws of a game, newest first.
+// Like GetDrawHistory, fixtures and imports are read from the source instead of the store.
+func GetLatestDrawResults(gameId string, count int) ([]models.DrawResult, error) {
+	game, err := GetGameById(gameId)
+	if err != nil {
+		return nil, err
+	}
+
+	if count <= 0 || count > maxLatestDraws {
+		return nil, fmt.Errorf("the number of draws must be between 1 and %d", maxLatestDraws)
+	}
+
+	if !IsDrawSourceStored() {
+		return getSourceLatestDrawResults(game, count, time.Now())
+	}
+
+	drawResults, err := store.GetLatestDraws(game.Id, count)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizeDrawResults(drawResults, game)
+
+	return drawResults, nil
+}
+
+// getSourceDrawHistory reads the draws between from and to from the draw source, month by month.
+// Months before the first draw of the game are left out, any other month the source cannot serve
+// fails the request.
+func getSourceDrawHistory(game *models.Game, from time.Time, to time.Time) ([]models.DrawResult, error) {
+	if firstDrawDate, err := getFirstDrawDate(game); err == nil && from.Before(firstDrawDate) {
+		from = firstDrawDate
+	}
+
+	drawResults := []models.DrawResult{}
+	for month := getMonthStart(from); !month.After(to); month = month.AddDate(0, 1, 0) {
+		monthDrawResults, err := GetDrawSource().GetDrawResults(game, strconv.Itoa(int(month.Month())), strconv.Itoa(month.Year()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s %02d/%d from the %s source: %w", game.Id, month.Month(), month.Year(), GetDrawSource().Name(), err)
+		}
+
+		drawResults = append(drawResults, getDrawResultsBetween(monthDrawResults, from, to)...)
+	}
+
+	normalizeDrawResults(drawResults, game)
+
+	return drawResults, nil
+}
+
+// getSourceLatestDrawResults walks the months of the draw source back from now until it finds
+// count draws or reaches the first draw of the game. A fixtures directory covers only some months,
+// so the months the source cannot serve are skipped; the first such error is returned only when
+// no draw was found at all.
+func getSourceLatestDrawResults(game *models.Game, count int, now time.Time) ([]models.DrawResult, error) {
+	firstDrawDate, err := getFirstDrawDate(game)
+	if err != nil {
+		return nil, err
+	}
+
+	if firstDrawDate.IsZero() {
+		firstDrawDate = getMonthStart(now).AddDate(0, 1-maxLatestSourceMonths, 0)
+	}
+
+	drawResults := []models.DrawResult{}
+	var sourceErr error
+	for month := getMonthStart(now); len(drawResults) < count && !month.Before(getMonthStart(firstDrawDate)); month = month.AddDate(0, -1, 0) {
+		monthDrawResults, err := GetDrawSource().GetDrawResults(game, strconv.Itoa(int(month.Month())), strconv.Itoa(month.Year()))
+		if err != nil {
+			if sourceErr == nil {
+				sourceErr = fmt.Errorf("failed to read %s %02d/%d from the %s source: %w", game.Id, month.Month(), month.Year(), GetDrawSource().Name(), err)
+			}
+
+			continue
+		}
+
+		monthDrawResults = getDrawResultsBetween(monthDrawResults, getMonthStart(month), now)
+		for i := len(monthDrawResults) - 1; i >= 0 && len(drawResults) < count; i-- {
+			drawResults = append(drawResults, monthDrawResults[i])
+		}
+	}
+
+	if len(drawResults) == 0 && sourceErr != nil {
+		return nil, sourceErr
+	}
+
+	normalizeDrawResults(drawResults, game)
+
+	return drawResults, nil
+}
@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"loto-suite/backend/models"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGetDrawResult(t *testing.T) {
+	useDrawSource(t, newFakeDrawSource(newTestDraw("2024-03-28", 1, 2, 3, 4, 5, 6), newTestDraw("2024-03-31", 7, 8, 9, 10, 11, 12)))
+
+	tests := []struct {
+		name   string
+		gameId string
+		date   time.Time
+		err    error
+	}{
+		{"draw of the month", "649", time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), nil},
+		{"no draw on the date", "649", time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC), ErrDrawNotFound},
+		{"no draws in the month", "649", time.Date(2024, 4, 4, 0, 0, 0, 0, time.UTC), ErrDrawNotFound},
+		{"unsupported game", "keno", time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), ErrUnsupportedGame},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drawResult, err := GetDrawResult(tt.gameId, tt.date)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("expected %v, got %v", tt.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if drawResult.GameDate != tt.date.Format("2006-01-02") {
+				t.Errorf("expected the draw of %s, got %s", tt.date.Format("2006-01-02"), drawResult.GameDate)
+			}
+		})
+	}
+}
+
+func TestGetDrawHistoryFromSource(t *testing.T) {
+	source := newFakeDrawSource(
+		newTestDraw("2024-02-29", 1, 2, 3, 4, 5, 6),
+		newTestDraw("2024-03-03", 7, 8, 9, 10, 11, 12),
+		newTestDraw("2024-03-31", 13, 14, 15, 16, 17, 18),
+		newTestDraw("2024-04-04", 19, 20, 21, 22, 23, 24),
+	)
+	useDrawSource(t, source)
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		expected []string
+	}{
+		{"range across months", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 4, 0, 0, 0, 0, time.UTC), []string{"2024-02-29", "2024-03-03", "2024-03-31", "2024-04-04"}},
+		{"range inside a month", time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), []string{"2024-03-31"}},
+		{"range without draws", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC), []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drawResults, err := GetDrawHistory("649", tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if dates := getDrawDates(drawResults); !slices.Equal(dates, tt.expected) {
+				t.Errorf("expected draws %v, got %v", tt.expected, dates)
+			}
+		})
+	}
+
+	if calls := source.calls["649_3_2024"]; calls == 0 {
+		t.Error("expected the draws of 03/2024 to be read from the draw source")
+	}
+
+	source.errs["649_6_2024"] = errors.New("no fixture")
+	if _, err := GetDrawHistory("649", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Error("expected a month the source cannot serve to fail the request")
+	}
+}
+
+func TestGetLatestDrawResultsFromSource(t *testing.T) {
+	source := newFakeDrawSource(
+		newTestDraw("2024-01-04", 1, 2, 3, 4, 5, 6),
+		newTestDraw("2024-03-03", 7, 8, 9, 10, 11, 12),
+		newTestDraw("2024-03-07", 13, 14, 15, 16, 17, 18),
+		newTestDraw("2024-04-04", 19, 20, 21, 22, 23, 24),
+	)
+	source.errs["649_2_2024"] = errors.New("no fixture")
+	useDrawSource(t, source)
+
+	now := time.Date(2024, 4, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		count    int
+		expected []string
+	}{
+		{"newest first", 2, []string{"2024-03-07", "2024-03-03"}},
+		{"skips the months the source cannot serve", 3, []string{"2024-03-07", "2024-03-03", "2024-01-04"}},
+		{"fewer draws than requested", 10, []string{"2024-03-07", "2024-03-03", "2024-01-04"}},
+	}
+
+	game, err := GetGameById("649")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drawResults, err := getSourceLatestDrawResults(game, tt.count, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if dates := getDrawDates(drawResults); !slices.Equal(dates, tt.expected) {
+				t.Errorf("expected draws %v, got %v", tt.expected, dates)
+			}
+		})
+	}
+}
+
+func TestExportDrawHistoryFromSource(t *testing.T) {
+	useDrawSource(t, newFakeDrawSource(newTestDraw("2024-03-03", 7, 8, 9, 10, 11, 12), newTestDraw("2024-03-07", 13, 14, 15, 16, 17, 18)))
+
+	var buffer bytes.Buffer
+	err := ExportDrawHistory(&buffer, "649", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), DrawFormatCsv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	drawResults, err := ReadDrawResultsCsv(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dates := getDrawDates(drawResults); !slices.Equal(dates, []string{"2024-03-03"}) {
+		t.Errorf("expected the draw of 2024-03-03, got %v", dates)
+	}
+}
+
+func getDrawDates(drawResults []models.DrawResult) []string {
+	dates := []string{}
+	for _, drawResult := range drawResults {
+		dates = append(dates, drawResult.GameDate)
+	}
+
+	return dates
+}
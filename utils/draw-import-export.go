@@ -0,0 +1,251 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"loto-suite/backend/cache"
+	"loto-suite/backend/generics"
+	"loto-suite/backend/models"
+	"loto-suite/backend/store"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DrawFormatCsv  = "csv"
+	DrawFormatJson = "json"
+)
+
+// DrawImportValidationError is returned when some draws of an import break the game rules.
+// Nothing is stored then, so the file can be fixed and imported again.
+type DrawImportValidationError struct {
+	Errors []models.DrawImportError
+}
+
+func (e *DrawImportValidationError) Error() string {
+	messages := []string{}
+	for _, importError := range e.Errors {
+		messages = append(messages, fmt.Sprintf("draw %d (%s %s): %s", importError.Index, importError.GameId, importError.DrawDate, strings.Join(importError.Problems, ", ")))
+	}
+
+	return fmt.Sprintf("invalid draws: %s", strings.Join(messages, "; "))
+}
+
+// GetDrawFormat returns the import/export format named by format, or else the one of the extension of fileName.
+func GetDrawFormat(format string, fileName string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileName)), ".")
+	}
+
+	switch format {
+	case DrawFormatCsv, DrawFormatJson:
+		return format, nil
+	case "":
+		return "", fmt.Errorf("the format is required, %s or %s", DrawFormatCsv, DrawFormatJson)
+	default:
+		return "", fmt.Errorf("unsupported format %q, expected %s or %s", format, DrawFormatCsv, DrawFormatJson)
+	}
+}
+
+// ExportDrawHistory writes the draws of a game between from and to (inclusive), with their prize categories,
+// as returned by GetDrawHistory.
+func ExportDrawHistory(w io.Writer, gameId string, from time.Time, to time.Time, format string) error {
+	drawResults, err := GetDrawHistory(gameId, from, to)
+	if err != nil {
+		return err
+	}
+
+	return WriteDrawResults(w, drawResults, format)
+}
+
+func WriteDrawResults(w io.Writer, drawResults []models.DrawResult, format string) error {
+	switch format {
+	case DrawFormatCsv:
+		return WriteDrawResultsCsv(w, drawResults)
+	case DrawFormatJson:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(drawResults)
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func ReadDrawResults(r io.Reader, format string) ([]models.DrawResult, error) {
+	switch format {
+	case DrawFormatCsv:
+		return ReadDrawResultsCsv(r)
+	case DrawFormatJson:
+		return readDrawResultsJson(r)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// ImportDrawResults validates the draws read from r against the rules of their games and adds
+// them to the local store, replacing the stored draws of the same dates. When any draw is
+// invalid, a DrawImportValidationError lists all of them and nothing is stored.
+// The cached results of every month touched are dropped, and a month that now has all its draws,
+// fully published, is marked complete like a scraped one.
+func ImportDrawResults(r io.Reader, format string) (*models.DrawImportReport, error) {
+	drawResults, err := ReadDrawResults(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(drawResults) == 0 {
+		return nil, fmt.Errorf("there are no draws to import")
+	}
+
+	now := time.Now()
+	importErrors := []models.DrawImportError{}
+	imported := map[string]bool{}
+	drawsByGame := map[string][]models.DrawResult{}
+
+	for i, drawResult := range drawResults {
+		game, err := GetGameById(drawResult.GameId)
+		if err != nil {
+			return nil, err
+		}
+
+		problems := getImportedDrawProblems(game, drawResult, now)
+
+		key := fmt.Sprintf("%s_%s", drawResult.GameId, drawResult.GameDate)
+		if imported[key] {
+			problems = append(problems, "the draw appears more than once")
+		}
+
+		imported[key] = true
+
+		if len(problems) > 0 {
+			importErrors = append(importErrors, models.DrawImportError{
+				Index:    i + 1,
+				GameId:   drawResult.GameId,
+				DrawDate: drawResult.GameDate,
+				Problems: problems,
+			})
+
+			continue
+		}
+
+		drawsByGame[game.Id] = append(drawsByGame[game.Id], drawResult)
+	}
+
+	if len(importErrors) > 0 {
+		return nil, &DrawImportValidationError{Errors: importErrors}
+	}
+
+	report := models.DrawImportReport{
+		Games: map[string]int{},
+	}
+
+	for gameId, gameDrawResults := range drawsByGame {
+		if err := store.SaveDraws(gameId, gameDrawResults); err != nil {
+			return nil, err
+		}
+
+		for _, month := range getDrawMonths(gameDrawResults) {
+			if err := refreshImportedMonth(gameId, int(month.Month()), month.Year(), now); err != nil {
+				return nil, err
+			}
+		}
+
+		report.Games[gameId] = len(gameDrawResults)
+		report.Imported += len(gameDrawResults)
+	}
+
+	return &report, nil
+}
+
+// getDrawMonths returns the start of every month with one of the draws.
+func getDrawMonths(drawResults []models.DrawResult) []time.Time {
+	months := []time.Time{}
+	for _, drawResult := range drawResults {
+		drawDate, err := generics.TryParseDate(drawResult.GameDate)
+		if err != nil {
+			continue
+		}
+
+		if month := getMonthStart(drawDate); !slices.ContainsFunc(months, month.Equal) {
+			months = append(months, month)
+		}
+	}
+
+	return months
+}
+
+// refreshImportedMonth drops the cached results of a month whose stored draws changed, and marks
+// the month complete once every draw of it is stored and fully published.
+func refreshImportedMonth(gameId string, month int, year int, now time.Time) error {
+	cache.Delete(gameId, strconv.Itoa(month), strconv.Itoa(year))
+
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, -1)
+
+	drawResults, err := store.GetDrawsBetween(gameId, from, to)
+	if err != nil {
+		return err
+	}
+
+	if len(drawResults) != countDrawDates(from, to) || !isMonthComplete(drawResults, month, year, now) {
+		return nil
+	}
+
+	return store.SaveMonth(gameId, month, year, drawResults)
+}
+
+// getImportedDrawProblems checks an imported draw like a scraped one, except that the prize
+// categories are optional: when present they must be categories of the game.
+func getImportedDrawProblems(game *models.Game, drawResult models.DrawResult, now time.Time) []string {
+	problems := getDrawNumbersProblems(game, drawResult)
+	addProblem := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	drawDate, err := generics.TryParseDate(drawResult.GameDate)
+	switch {
+	case err != nil:
+		addProblem("invalid draw date")
+	case !isDrawDay(drawDate):
+		addProblem("%s is not a draw day", drawDate.Weekday())
+	case getDrawTime(drawDate).After(now):
+		addProblem("the draw has not taken place yet")
+	}
+
+	variantCategoryIds := map[string]bool{}
+	for _, categorie := range game.VariantCategories {
+		variantCategoryIds[categorie.Id] = true
+	}
+
+	luckyNumberCategoryIds := map[string]bool{}
+	for _, categorie := range game.LuckyNumberCategories {
+		luckyNumberCategoryIds[categorie.Id] = true
+	}
+
+	checkCategories := func(categoriiCastig []models.WinCategory, categoryIds map[string]bool, table string) {
+		for _, categorie := range categoriiCastig {
+			if !categoryIds[categorie.Id] {
+				addProblem("unknown prize category %q for %s", categorie.Id, table)
+			}
+
+			if categorie.WinnersCount < 0 || categorie.Amount < 0 || categorie.Report < 0 {
+				addProblem("negative values in prize category %q for %s", categorie.Id, table)
+			}
+		}
+	}
+
+	checkCategories(drawResult.WinCategoriesVariantRegular, variantCategoryIds, "the variant")
+	checkCategories(drawResult.WinCategoriesVariantSpecial, variantCategoryIds, "the special draw")
+	checkCategories(drawResult.WinCategoriesLuckyNumber, luckyNumberCategoryIds, game.LuckyNumberName)
+
+	if drawResult.VariantSpecial == nil && len(drawResult.WinCategoriesVariantSpecial) > 0 {
+		addProblem("prize categories for a special draw without numbers")
+	}
+
+	return problems
+}
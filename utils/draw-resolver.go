@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"fmt"
+	"loto-suite/backend/generics"
+	"loto-suite/backend/models"
+	"strconv"
+	"time"
+)
+
+// drawResultsResolver memoizes GetDrawResults per game and month for the lifetime of
+// a single request, so checks spanning several draws resolve each month only once.
+// A month that failed to resolve keeps its error, so a batch does not retry it per ticket.
+type drawResultsResolver struct {
+	results map[string][]models.DrawResult
+	errors  map[string]error
+}
+
+func newDrawResultsResolver() *drawResultsResolver {
+	return &drawResultsResolver{
+		results: make(map[string][]models.DrawResult),
+		errors:  make(map[string]error),
+	}
+}
+
+func (r *drawResultsResolver) month(gameId string, month string, year string) ([]models.DrawResult, error) {
+	key := fmt.Sprintf("%s_%s_%s", gameId, month, year)
+	if drawResults, found := r.results[key]; found {
+		return drawResults, nil
+	}
+
+	if err, found := r.errors[key]; found {
+		return nil, err
+	}
+
+	drawResults, err := GetDrawResults(gameId, month, year)
+	if err != nil {
+		r.errors[key] = fmt.Errorf("failed to get draw results: %w", err)
+		return nil, r.errors[key]
+	}
+
+	r.results[key] = drawResults
+
+	return drawResults, nil
+}
+
+// refresh replaces the memoized results of a month with freshly scraped ones.
+func (r *drawResultsResolver) refresh(gameId string, date time.Time) error {
+	month := strconv.Itoa(int(date.Month()))
+	year := strconv.Itoa(date.Year())
+
+	drawResults, err := RefreshDrawResults(gameId, month, year)
+	if err != nil {
+		return fmt.Errorf("failed to refresh draw results: %w", err)
+	}
+
+	key := fmt.Sprintf("%s_%s_%s", gameId, month, year)
+	r.results[key] = drawResults
+	delete(r.errors, key)
+
+	return nil
+}
+
+func (r *drawResultsResolver) find(gameId string, date time.Time) (*models.DrawResult, error) {
+	month := strconv.Itoa(int(date.Month()))
+	year := strconv.Itoa(date.Year())
+
+	drawResults, err := r.month(gameId, month, year)
+	if err != nil {
+		return nil, err
+	}
+
+	drawResult, found := generics.FindFirst(drawResults, func(dr models.DrawResult) bool {
+		drawDate, err := generics.TryParseDate(dr.GameDate)
+		return err == nil && drawDate.Equal(date)
+	})
+
+	if !found || drawResult.GameId == "" {
+		return nil, ErrDrawNotFound
+	}
+
+	return &drawResult, nil
+}
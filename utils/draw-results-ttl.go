@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"encoding/json"
+	"loto-suite/backend/cache"
+	"loto-suite/backend/generics"
+	"loto-suite/backend/models"
+	"strconv"
+	"time"
+)
+
+const (
+	// missingDrawTtl is how long a month is cached while its latest draw is not published yet.
+	missingDrawTtl = 5 * time.Minute
+	// pendingPrizesTtl is how long a month is cached while prize amounts of its latest draw are pending.
+	pendingPrizesTtl = time.Hour
+	// prizesPublishedWithin is how long after a draw all its prize amounts are surely published:
+	// a category still without an amount by then was not won.
+	prizesPublishedWithin = 7 * 24 * time.Hour
+)
+
+// cacheDrawResults caches the results of a month for as long as they cannot change: complete months
+// forever, the others until the next draw once their latest draw is fully published.
+func cacheDrawResults(game *models.Game, month string, year string, drawResults []models.DrawResult, now time.Time) {
+	data, err := json.Marshal(drawResults)
+	if err != nil {
+		return
+	}
+
+	monthInt, err1 := strconv.Atoi(month)
+	yearInt, err2 := strconv.Atoi(year)
+	if err1 == nil && err2 == nil && isMonthComplete(drawResults, monthInt, yearInt, now) {
+		cache.SetPermanent(game.Id, month, year, data)
+		return
+	}
+
+	cache.Set(game.Id, month, year, data, getDrawResultsTtl(drawResults, monthInt, yearInt, now))
+}
+
+// isMonthComplete reports whether the results of a month can no longer change: the month is closed
+// and its last draw is listed with all its prize tables and amounts published.
+func isMonthComplete(drawResults []models.DrawResult, month int, year int, now time.Time) bool {
+	if !isClosedMonth(month, year, now) {
+		return false
+	}
+
+	lastDrawDate, found := getLastDrawDateOfMonth(month, year, now)
+	if !found {
+		return false
+	}
+
+	lastDraw, found := findDrawResult(drawResults, lastDrawDate)
+	if !found || !hasPrizeTables(lastDraw) {
+		return false
+	}
+
+	return !hasPendingPrizes(lastDraw) || now.After(getDrawTime(lastDrawDate).Add(prizesPublishedWithin))
+}
+
+// getLastDrawDateOfMonth returns the last draw day of a month that took place before now.
+func getLastDrawDateOfMonth(month int, year int, now time.Time) (time.Time, bool) {
+	monthStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	for d := monthStart.AddDate(0, 1, -1); !d.Before(monthStart); d = d.AddDate(0, 0, -1) {
+		if isDrawDay(d) && !now.Before(getDrawTime(d)) {
+			return d, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func findDrawResult(drawResults []models.DrawResult, date time.Time) (models.DrawResult, bool) {
+	return generics.FindFirst(drawResults, func(dr models.DrawResult) bool {
+		drawDate, err := generics.TryParseDate(dr.GameDate)
+		return err == nil && drawDate.Equal(date)
+	})
+}
+
+func getDrawResultsTtl(drawResults []models.DrawResult, month int, year int, now time.Time) time.Duration {
+	untilNextDraw := missingDrawTtl
+	today, _ := generics.TryParseDate(now.Format(generics.GoDateFormat))
+	for _, drawDate := range GetNextDrawDates(today, 2) {
+		if drawTime := getDrawTime(drawDate); drawTime.After(now) {
+			untilNextDraw = drawTime.Sub(now)
+			break
+		}
+	}
+
+	latestDrawDate, found := getLastDrawDateOfMonth(month, year, now)
+	if !found {
+		// No draw of the month took place yet
+		return untilNextDraw
+	}
+
+	latestDraw, found := findDrawResult(drawResults, latestDrawDate)
+	if !found {
+		return missingDrawTtl
+	}
+
+	if hasPendingPrizes(latestDraw) {
+		return min(pendingPrizesTtl, untilNextDraw)
+	}
+
+	return untilNextDraw
+}
+
+// hasPendingPrizes reports whether a prize table or a prize amount of a draw is not published yet.
+func hasPendingPrizes(drawResult models.DrawResult) bool {
+	if !hasPrizeTables(drawResult) {
+		return true
+	}
+
+	for _, categorii := range [][]models.WinCategory{drawResult.WinCategoriesVariantRegular, drawResult.WinCategoriesVariantSpecial, drawResult.WinCategoriesLuckyNumber} {
+		for _, categorie := range categorii {
+			if getPrizeState(categorie) == models.PrizeStatePending {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func hasPrizeTables(drawResult models.DrawResult) bool {
+	return len(drawResult.WinCategoriesVariantRegular) > 0 && len(drawResult.WinCategoriesLuckyNumber) > 0 &&
+		(drawResult.VariantSpecial == nil || len(drawResult.WinCategoriesVariantSpecial) > 0)
+}
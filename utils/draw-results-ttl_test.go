@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"loto-suite/backend/models"
+	"testing"
+	"time"
+)
+
+// newTestDrawWithPendingPrize returns a draw whose second category was won but has no amount yet.
+func newTestDrawWithPendingPrize(date string) models.DrawResult {
+	drawResult := newTestDraw(date, 1, 2, 3, 4, 5, 6)
+	drawResult.WinCategoriesVariantRegular[1].Amount = 0
+	drawResult.WinCategoriesVariantRegular[1].State = ""
+
+	return drawResult
+}
+
+func newTestDrawWithoutPrizes(date string) models.DrawResult {
+	drawResult := newTestDraw(date, 1, 2, 3, 4, 5, 6)
+	drawResult.WinCategoriesVariantRegular = nil
+	drawResult.WinCategoriesLuckyNumber = nil
+
+	return drawResult
+}
+
+func TestIsMonthComplete(t *testing.T) {
+	tests := []struct {
+		name        string
+		drawResults []models.DrawResult
+		now         time.Time
+		expected    bool
+	}{
+		{
+			name:        "closed month with its last draw published",
+			drawResults: []models.DrawResult{newTestDraw("2024-03-28", 1, 2, 3, 4, 5, 6), newTestDraw("2024-03-31", 1, 2, 3, 4, 5, 6)},
+			now:         time.Date(2024, 4, 10, 10, 0, 0, 0, time.Local),
+			expected:    true,
+		},
+		{
+			name:        "current month",
+			drawResults: []models.DrawResult{newTestDraw("2024-03-31", 1, 2, 3, 4, 5, 6)},
+			now:         time.Date(2024, 3, 31, 22, 0, 0, 0, time.Local),
+			expected:    false,
+		},
+		{
+			name:        "last draw missing",
+			drawResults: []models.DrawResult{newTestDraw("2024-03-28", 1, 2, 3, 4, 5, 6)},
+			now:         time.Date(2024, 4, 10, 10, 0, 0, 0, time.Local),
+			expected:    false,
+		},
+		{
+			name:        "last draw without prize tables",
+			drawResults: []models.DrawResult{newTestDrawWithoutPrizes("2024-03-31")},
+			now:         time.Date(2024, 4, 10, 10, 0, 0, 0, time.Local),
+			expected:    false,
+		},
+		{
+			name:        "last draw with a pending prize",
+			drawResults: []models.DrawResult{newTestDrawWithPendingPrize("2024-03-31")},
+			now:         time.Date(2024, 4, 5, 10, 0, 0, 0, time.Local),
+			expected:    false,
+		},
+		{
+			name:        "pending prize past the publication delay",
+			drawResults: []models.DrawResult{newTestDrawWithPendingPrize("2024-03-31")},
+			now:         time.Date(2024, 4, 10, 10, 0, 0, 0, time.Local),
+			expected:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if complete := isMonthComplete(tt.drawResults, 3, 2024, tt.now); complete != tt.expected {
+				t.Errorf("expected complete %v, got %v", tt.expected, complete)
+			}
+		})
+	}
+}
+
+func TestGetDrawResultsTtl(t *testing.T) {
+	tests := []struct {
+		name        string
+		drawResults []models.DrawResult
+		month       int
+		now         time.Time
+		expected    time.Duration
+	}{
+		{
+			name:        "latest draw published",
+			drawResults: []models.DrawResult{newTestDraw("2024-04-04", 1, 2, 3, 4, 5, 6)},
+			month:       4,
+			now:         time.Date(2024, 4, 4, 22, 0, 0, 0, time.Local),
+			expected:    71 * time.Hour,
+		},
+		{
+			name:        "latest draw missing",
+			drawResults: []models.DrawResult{},
+			month:       4,
+			now:         time.Date(2024, 4, 4, 22, 0, 0, 0, time.Local),
+			expected:    missingDrawTtl,
+		},
+		{
+			name:        "latest draw with a pending prize",
+			drawResults: []models.DrawResult{newTestDrawWithPendingPrize("2024-04-04")},
+			month:       4,
+			now:         time.Date(2024, 4, 4, 22, 0, 0, 0, time.Local),
+			expected:    pendingPrizesTtl,
+		},
+		{
+			name:        "pending prize shortly before the next draw",
+			drawResults: []models.DrawResult{newTestDrawWithPendingPrize("2024-04-04")},
+			month:       4,
+			now:         time.Date(2024, 4, 7, 20, 30, 0, 0, time.Local),
+			expected:    30 * time.Minute,
+		},
+		{
+			name:        "no draw of the month yet",
+			drawResults: []models.DrawResult{},
+			month:       5,
+			now:         time.Date(2024, 5, 1, 10, 0, 0, 0, time.Local),
+			expected:    35 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ttl := getDrawResultsTtl(tt.drawResults, tt.month, 2024, tt.now); ttl != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, ttl)
+			}
+		})
+	}
+}
@@ -4,37 +4,167 @@ import (
 	"encoding/json"
 	"fmt"
 	"loto-suite/backend/cache"
+	"loto-suite/backend/logging"
 	"loto-suite/backend/models"
+	"loto-suite/backend/store"
+	"strconv"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
+var scrapeGroup singleflight.Group
+
 func GetDrawResults(gameId string, month string, year string) ([]models.DrawResult, error) {
 	if gameId == "" {
 		return nil, fmt.Errorf("game ID is required")
 	}
 
+	game, err := GetGameById(gameId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !IsDrawSourceStored() {
+		return GetDrawSource().GetDrawResults(game, month, year)
+	}
+
 	if cachedData, found := cache.Get(gameId, month, year); found {
 		var results []models.DrawResult
 		if err := json.Unmarshal(cachedData, &results); err == nil {
+			// Results cached before the secondary pool was modelled carry the joker as the last number
+			normalizeDrawResults(results, game)
 			return results, nil
 		}
 	}
 
+	if drawResults, found := getStoredDrawResults(game, month, year); found {
+		if data, marshalErr := json.Marshal(drawResults); marshalErr == nil {
+			cache.SetPermanent(game.Id, month, year, data)
+		}
+
+		return drawResults, nil
+	}
+
+	drawResults, err := refreshDrawResults(game, month, year)
+	if err != nil {
+		if staleResults, found := getStaleDrawResults(game, month, year); found {
+			logging.Error("upstream", fmt.Errorf("serving stale results for %s %s/%s: %w", game.Id, month, year, err), "")
+			return staleResults, nil
+		}
+
+		return nil, err
+	}
+
+	return drawResults, nil
+}
+
+// getStaleDrawResults returns the last known results of a month when they cannot be scraped:
+// the expired cache entry, or else the draws of the month kept in the local store.
+func getStaleDrawResults(game *models.Game, month string, year string) ([]models.DrawResult, bool) {
+	if cachedData, _, found := cache.GetStale(game.Id, month, year); found {
+		var results []models.DrawResult
+		if err := json.Unmarshal(cachedData, &results); err == nil {
+			normalizeDrawResults(results, game)
+			return results, true
+		}
+	}
+
+	monthInt, err1 := strconv.Atoi(month)
+	yearInt, err2 := strconv.Atoi(year)
+	if err1 != nil || err2 != nil {
+		return nil, false
+	}
+
+	from := time.Date(yearInt, time.Month(monthInt), 1, 0, 0, 0, 0, time.UTC)
+	drawResults, err := store.GetDrawsBetween(game.Id, from, from.AddDate(0, 1, -1))
+	if err != nil || len(drawResults) == 0 {
+		return nil, false
+	}
+
+	normalizeDrawResults(drawResults, game)
+
+	return drawResults, true
+}
+
+// RefreshDrawResults fetches the results of a month again from the draw source, bypassing the cache, and caches them.
+// It is used to pick up a draw published after the month was cached.
+func RefreshDrawResults(gameId string, month string, year string) ([]models.DrawResult, error) {
 	game, err := GetGameById(gameId)
 	if err != nil {
-		fmt.Println(err.Error())
 		return nil, err
 	}
 
-	results, err := scrapeDrawResults(game, month, year)
+	return refreshDrawResults(game, month, year)
+}
+
+// refreshDrawResults fetches a month from the draw source once for all the concurrent callers asking for it:
+// right after a draw many checks miss the cache together, and they share one upstream request.
+func refreshDrawResults(game *models.Game, month string, year string) ([]models.DrawResult, error) {
+	key := fmt.Sprintf("%s_%s_%s", game.Id, month, year)
+
+	results, err, _ := scrapeGroup.Do(key, func() (any, error) {
+		source := GetDrawSource()
+		results, err := source.GetDrawResults(game, month, year)
+		if err != nil {
+			logging.Error("draw-source", err, "")
+			return nil, err
+		}
+
+		if source.Name() != DrawSourceScraper {
+			return results, nil
+		}
+
+		cacheDrawResults(game, month, year, results, time.Now())
+
+		storeDrawResults(game, month, year, results)
+
+		return results, nil
+	})
+
 	if err != nil {
-		fmt.Println(err.Error())
 		return nil, err
 	}
 
-	if data, marshalErr := json.Marshal(results); marshalErr == nil {
-		cache.Set(gameId, month, year, data, 24*time.Hour)
+	// Each caller gets its own slice, the draws themselves are shared read-only
+	return append([]models.DrawResult{}, results.([]models.DrawResult)...), nil
+}
+
+// getStoredDrawResults returns the results of a closed month from the local store, if all its draws are stored.
+func getStoredDrawResults(game *models.Game, month string, year string) ([]models.DrawResult, bool) {
+	monthInt, err1 := strconv.Atoi(month)
+	yearInt, err2 := strconv.Atoi(year)
+	if err1 != nil || err2 != nil || !isClosedMonth(monthInt, yearInt, time.Now()) {
+		return nil, false
+	}
+
+	drawResults, found := store.GetMonth(game.Id, monthInt, yearInt)
+	if !found {
+		return nil, false
+	}
+
+	normalizeDrawResults(drawResults, game)
+
+	return drawResults, true
+}
+
+// storeDrawResults keeps every scraped draw in the local store. A complete month can no longer
+// change, so it is also marked complete and is not scraped again.
+func storeDrawResults(game *models.Game, month string, year string, drawResults []models.DrawResult) {
+	monthInt, err1 := strconv.Atoi(month)
+	yearInt, err2 := strconv.Atoi(year)
+	if err1 != nil || err2 != nil || len(drawResults) == 0 {
+		return
 	}
 
-	return results, nil
+	var err error
+	if isMonthComplete(drawResults, monthInt, yearInt, time.Now()) {
+		err = store.SaveMonth(game.Id, monthInt, yearInt, drawResults)
+	} else {
+		err = store.SaveDraws(game.Id, drawResults)
+	}
+
+	if err != nil {
+		logging.Error("store", err, "")
+	}
 }
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"fmt"
+	"loto-suite/backend/generics"
+	"loto-suite/backend/logging"
+	"loto-suite/backend/models"
+	"loto-suite/backend/store"
+	"strconv"
+	"time"
+)
+
+// drawPollWindow is how long after a draw the scheduler keeps polling for its results.
+const drawPollWindow = 48 * time.Hour
+
+// drawPoll tracks the polling of a game for the results of one draw.
+type drawPoll struct {
+	drawDate  time.Time
+	attempts  int
+	available bool
+}
+
+// StartDrawScheduler polls loto.ro every interval after each draw time until the results of the
+// draw show up, so that they are in the cache and store before the first user asks for them.
+func StartDrawScheduler(interval time.Duration) {
+	go func() {
+		polls := map[string]*drawPoll{}
+
+		for {
+			pollLatestDraws(polls, time.Now())
+			time.Sleep(getNextPollDelay(polls, interval, time.Now()))
+		}
+	}()
+}
+
+func pollLatestDraws(polls map[string]*drawPoll, now time.Time) {
+	drawDate, found := getLatestDrawDate(now)
+	if !found || now.After(getDrawTime(drawDate).Add(drawPollWindow)) {
+		return
+	}
+
+	for _, game := range models.Games {
+		poll, found := polls[game.Id]
+		if !found || !poll.drawDate.Equal(drawDate) {
+			poll = &drawPoll{drawDate: drawDate}
+			polls[game.Id] = poll
+
+			// Results already known, e.g. stored before a restart
+			if _, stored := store.GetDraw(game.Id, drawDate); stored {
+				poll.available = true
+			}
+		}
+
+		if !poll.available {
+			pollDraw(game, poll, now)
+		}
+	}
+}
+
+func pollDraw(game *models.Game, poll *drawPoll, now time.Time) {
+	poll.attempts++
+
+	month := strconv.Itoa(int(poll.drawDate.Month()))
+	year := strconv.Itoa(poll.drawDate.Year())
+
+	drawResults, err := RefreshDrawResults(game.Id, month, year)
+	if err != nil {
+		logging.Error("scheduler", fmt.Errorf("%s draw of %s not available yet (attempt %d): %w",
+			game.Id, poll.drawDate.Format(generics.GoDateFormat), poll.attempts, err), "")
+		return
+	}
+
+	_, found := generics.FindFirst(drawResults, func(dr models.DrawResult) bool {
+		drawDate, err := generics.TryParseDate(dr.GameDate)
+		return err == nil && drawDate.Equal(poll.drawDate)
+	})
+
+	if !found {
+		return
+	}
+
+	poll.available = true
+
+	availability := models.DrawAvailability{
+		GameId:      game.Id,
+		DrawDate:    poll.drawDate.Format(generics.GoDateFormat),
+		DrawAt:      getDrawTime(poll.drawDate),
+		AvailableAt: now,
+		Attempts:    poll.attempts,
+	}
+
+	logging.Info("scheduler", fmt.Sprintf("%s draw of %s available %s after the draw time",
+		game.Id, availability.DrawDate, now.Sub(availability.DrawAt).Round(time.Minute)))
+
+	if err := store.SaveDrawAvailability(availability); err != nil {
+		logging.Error("scheduler", err, "")
+	}
+}
+
+// getNextPollDelay waits interval while the results of a draw are awaited, and otherwise
+// sleeps until the next draw time (at most a day, so a clock change is picked up).
+func getNextPollDelay(polls map[string]*drawPoll, interval time.Duration, now time.Time) time.Duration {
+	drawDate, found := getLatestDrawDate(now)
+	if found && !now.After(getDrawTime(drawDate).Add(drawPollWindow)) {
+		for _, game := range models.Games {
+			if poll, found := polls[game.Id]; !found || !poll.available {
+				return interval
+			}
+		}
+	}
+
+	today, _ := generics.TryParseDate(now.Format(generics.GoDateFormat))
+	for _, nextDrawDate := range GetNextDrawDates(today, 2) {
+		if nextDrawTime := getDrawTime(nextDrawDate); nextDrawTime.After(now) {
+			return min(nextDrawTime.Sub(now), 24*time.Hour)
+		}
+	}
+
+	return interval
+}
+
+// getLatestDrawDate returns the date of the last draw whose draw time has passed.
+func getLatestDrawDate(now time.Time) (time.Time, bool) {
+	today, _ := generics.TryParseDate(now.Format(generics.GoDateFormat))
+
+	for d := today; !d.Before(today.AddDate(0, 0, -7)); d = d.AddDate(0, 0, -1) {
+		if isDrawDay(d) && !now.Before(getDrawTime(d)) {
+			return d, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"loto-suite/backend/models"
+	"testing"
+	"time"
+)
+
+func TestGetLatestDrawDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected string
+	}{
+		{"before the draw time", time.Date(2024, 3, 28, 20, 59, 0, 0, time.Local), "2024-03-24"},
+		{"at the draw time", time.Date(2024, 3, 28, 21, 0, 0, 0, time.Local), "2024-03-28"},
+		{"between draws", time.Date(2024, 3, 30, 10, 0, 0, 0, time.Local), "2024-03-28"},
+		{"after a sunday draw", time.Date(2024, 3, 31, 23, 0, 0, 0, time.Local), "2024-03-31"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drawDate, found := getLatestDrawDate(tt.now)
+			if !found {
+				t.Fatal("expected a draw within the last week")
+			}
+
+			if date := drawDate.Format("2006-01-02"); date != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, date)
+			}
+		})
+	}
+}
+
+func TestGetNextPollDelay(t *testing.T) {
+	const interval = 10 * time.Minute
+
+	drawDate := time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC)
+	allAvailable := map[string]*drawPoll{}
+	someAvailable := map[string]*drawPoll{}
+	for i, game := range models.Games {
+		allAvailable[game.Id] = &drawPoll{drawDate: drawDate, available: true}
+		someAvailable[game.Id] = &drawPoll{drawDate: drawDate, available: i > 0}
+	}
+
+	tests := []struct {
+		name     string
+		polls    map[string]*drawPoll
+		now      time.Time
+		expected time.Duration
+	}{
+		{"results awaited", map[string]*drawPoll{}, time.Date(2024, 3, 28, 21, 30, 0, 0, time.Local), interval},
+		{"results of one game awaited", someAvailable, time.Date(2024, 3, 29, 10, 0, 0, 0, time.Local), interval},
+		{"all results in, next draw days away", allAvailable, time.Date(2024, 3, 28, 21, 30, 0, 0, time.Local), 24 * time.Hour},
+		{"all results in, next draw today", allAvailable, time.Date(2024, 3, 31, 10, 0, 0, 0, time.Local), 11 * time.Hour},
+		{"poll window over", map[string]*drawPoll{}, time.Date(2024, 3, 30, 22, 0, 0, 0, time.Local), 23 * time.Hour},
+		{"at the draw time", map[string]*drawPoll{}, time.Date(2024, 3, 31, 21, 0, 0, 0, time.Local), interval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if delay := getNextPollDelay(tt.polls, interval, tt.now); delay != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, delay)
+			}
+		})
+	}
+}
+
+func TestPollDraw(t *testing.T) {
+	source := newFakeDrawSource(newTestDraw("2024-03-28", 1, 2, 3, 4, 5, 6))
+	useDrawSource(t, source)
+
+	game, _ := GetGameById("649")
+	now := time.Date(2024, 3, 31, 21, 30, 0, 0, time.Local)
+
+	poll := &drawPoll{drawDate: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)}
+	pollDraw(game, poll, now)
+	pollDraw(game, poll, now)
+
+	if poll.available || poll.attempts != 2 {
+		t.Errorf("expected two attempts without results, got %+v", poll)
+	}
+
+	if calls := source.calls["649_3_2024"]; calls != 2 {
+		t.Errorf("expected every attempt to refresh the month, got %d requests", calls)
+	}
+}
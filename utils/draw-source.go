@@ -0,0 +1,256 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"loto-suite/backend/generics"
+	"loto-suite/backend/models"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DrawSource provides the results of the draws of a game in a month.
+type DrawSource interface {
+	Name() string
+	GetDrawResults(game *models.Game, month string, year string) ([]models.DrawResult, error)
+}
+
+const (
+	DrawSourceScraper  = "scraper"
+	DrawSourceFixtures = "fixtures"
+	DrawSourceImport   = "import"
+)
+
+var (
+	drawSource      DrawSource = scraperDrawSource{}
+	drawSourceMutex sync.RWMutex
+)
+
+// LoadDrawSource selects where the draw results come from: the loto.ro scraper (the default),
+// a directory of fixtures or an import file at path. The last two need no network at all.
+func LoadDrawSource(kind string, path string) error {
+	source, err := NewDrawSource(kind, path)
+	if err != nil {
+		return err
+	}
+
+	SetDrawSource(source)
+
+	return nil
+}
+
+func NewDrawSource(kind string, path string) (DrawSource, error) {
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "", DrawSourceScraper:
+		return scraperDrawSource{}, nil
+	case DrawSourceFixtures:
+		return newFixtureDrawSource(path)
+	case DrawSourceImport:
+		return newImportDrawSource(path)
+	default:
+		return nil, fmt.Errorf("unknown draw source %q, expected %s, %s or %s", kind, DrawSourceScraper, DrawSourceFixtures, DrawSourceImport)
+	}
+}
+
+func SetDrawSource(source DrawSource) {
+	drawSourceMutex.Lock()
+	defer drawSourceMutex.Unlock()
+
+	drawSource = source
+}
+
+func GetDrawSource() DrawSource {
+	drawSourceMutex.RLock()
+	defer drawSourceMutex.RUnlock()
+
+	return drawSource
+}
+
+// IsDrawSourceStored reports whether the results of the draw source go through the cache and the
+// local store. Fixtures and imports are served as they are, so they never mix with the scraped results.
+func IsDrawSourceStored() bool {
+	return GetDrawSource().Name() == DrawSourceScraper
+}
+
+// scraperDrawSource scrapes the results page of the game on loto.ro.
+type scraperDrawSource struct{}
+
+func (scraperDrawSource) Name() string {
+	return DrawSourceScraper
+}
+
+func (scraperDrawSource) GetDrawResults(game *models.Game, month string, year string) ([]models.DrawResult, error) {
+	return scrapeDrawResults(game, month, year)
+}
+
+// fixtureDrawSource reads the results of a month from <dir>/<game id>/<year>-<MM> with the
+// extension .html (a saved results page of loto.ro), .json or .csv.
+// A month without a fixture is an error, like a month the scraper cannot reach.
+type fixtureDrawSource struct {
+	dir string
+}
+
+func newFixtureDrawSource(dir string) (*fixtureDrawSource, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("the fixtures draw source needs a directory")
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open fixtures directory: %w", err)
+	}
+
+	if !info.IsDir() {
+		return nil, fmt.Errorf("fixtures path %s is not a directory", dir)
+	}
+
+	return &fixtureDrawSource{dir: dir}, nil
+}
+
+func (s *fixtureDrawSource) Name() string {
+	return DrawSourceFixtures
+}
+
+func (s *fixtureDrawSource) GetDrawResults(game *models.Game, month string, year string) ([]models.DrawResult, error) {
+	monthInt, err1 := strconv.Atoi(month)
+	yearInt, err2 := strconv.Atoi(year)
+	if err1 != nil || err2 != nil {
+		return nil, fmt.Errorf("invalid month %s/%s", month, year)
+	}
+
+	basePath := filepath.Join(s.dir, game.Id, fmt.Sprintf("%04d-%02d", yearInt, monthInt))
+
+	for _, extension := range []string{".html", ".json", ".csv"} {
+		file, err := os.Open(basePath + extension)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to open fixture: %w", err)
+		}
+
+		defer file.Close()
+
+		var drawResults []models.DrawResult
+		switch extension {
+		case ".html":
+			return parseResultsPage(game, month, year, file)
+		case ".json":
+			drawResults, err = readDrawResultsJson(file)
+		case ".csv":
+			drawResults, err = ReadDrawResultsCsv(file)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid fixture %s: %w", file.Name(), err)
+		}
+
+		return filterDrawResults(drawResults, game, monthInt, yearInt), nil
+	}
+
+	return nil, fmt.Errorf("no fixture for %s %s/%s in %s", game.Id, month, year, s.dir)
+}
+
+// importDrawSource serves the draws of a JSON or CSV file loaded once at startup.
+// Months missing from the file have no draws.
+type importDrawSource struct {
+	path        string
+	drawResults []models.DrawResult
+}
+
+func newImportDrawSource(path string) (*importDrawSource, error) {
+	if path == "" {
+		return nil, fmt.Errorf("the import draw source needs a file")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open import file: %w", err)
+	}
+
+	defer file.Close()
+
+	var drawResults []models.DrawResult
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		drawResults, err = readDrawResultsJson(file)
+	case ".csv":
+		drawResults, err = ReadDrawResultsCsv(file)
+	default:
+		return nil, fmt.Errorf("import file %s must be .json or .csv", path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid import file %s: %w", path, err)
+	}
+
+	return &importDrawSource{path: path, drawResults: drawResults}, nil
+}
+
+func (s *importDrawSource) Name() string {
+	return DrawSourceImport
+}
+
+func (s *importDrawSource) GetDrawResults(game *models.Game, month string, year string) ([]models.DrawResult, error) {
+	monthInt, err1 := strconv.Atoi(month)
+	yearInt, err2 := strconv.Atoi(year)
+	if err1 != nil || err2 != nil {
+		return nil, fmt.Errorf("invalid month %s/%s", month, year)
+	}
+
+	return filterDrawResults(s.drawResults, game, monthInt, yearInt), nil
+}
+
+// readDrawResultsJson reads a JSON array of draws, in the format served by the API.
+func readDrawResultsJson(r io.Reader) ([]models.DrawResult, error) {
+	var drawResults []models.DrawResult
+	if err := json.NewDecoder(r).Decode(&drawResults); err != nil {
+		return nil, err
+	}
+
+	for i := range drawResults {
+		game, err := GetGameById(strings.ToLower(strings.TrimSpace(drawResults[i].GameId)))
+		if err != nil {
+			return nil, fmt.Errorf("draw %d: %w", i+1, err)
+		}
+
+		drawDate, err := generics.TryParseDate(drawResults[i].GameDate)
+		if err != nil {
+			return nil, fmt.Errorf("draw %d: invalid date %q", i+1, drawResults[i].GameDate)
+		}
+
+		drawResults[i].GameId = game.Id
+		drawResults[i].GameDate = drawDate.Format(generics.GoDateFormat)
+		drawResults[i].LuckyNumberName = game.LuckyNumberName
+		normalizeDrawResult(&drawResults[i], game)
+		completeDrawResult(&drawResults[i])
+	}
+
+	return drawResults, nil
+}
+
+// filterDrawResults returns the draws of the game in the month.
+func filterDrawResults(drawResults []models.DrawResult, game *models.Game, month int, year int) []models.DrawResult {
+	filtered := []models.DrawResult{}
+	for _, drawResult := range drawResults {
+		if drawResult.GameId != game.Id {
+			continue
+		}
+
+		drawDate, err := time.Parse(generics.GoDateFormat, drawResult.GameDate)
+		if err != nil || int(drawDate.Month()) != month || drawDate.Year() != year {
+			continue
+		}
+
+		filtered = append(filtered, drawResult)
+	}
+
+	return filtered
+}
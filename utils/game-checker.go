@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"loto-suite/backend/models"
+	"sync"
+)
+
+var ErrUnsupportedGame = errors.New("unsupported game")
+
+// GameChecker scores a played ticket against a draw for a single game.
+// Implementations are registered by models.Game.Id and looked up by CheckTicket.
+type GameChecker interface {
+	CheckVariant(variantaJucata *models.Variant, variantaExtrasa *models.Variant, game *models.Game)
+	CheckLuckyNumber(norocJucat *models.LuckyNumber, norocCastigator *models.LuckyNumber, game *models.Game)
+	DefaultCategories(game *models.Game) []models.Win
+}
+
+var (
+	gameCheckers      = map[string]GameChecker{}
+	gameCheckersMutex sync.RWMutex
+)
+
+func RegisterGameChecker(gameId string, checker GameChecker) {
+	gameCheckersMutex.Lock()
+	defer gameCheckersMutex.Unlock()
+
+	gameCheckers[gameId] = checker
+}
+
+func GetGameChecker(gameId string) (GameChecker, error) {
+	gameCheckersMutex.RLock()
+	defer gameCheckersMutex.RUnlock()
+
+	checker, found := gameCheckers[gameId]
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedGame, gameId)
+	}
+
+	return checker, nil
+}
+
+func checkVariants(checker GameChecker, game *models.Game, checkResult *models.CheckResult) {
+	if len(checkResult.VarianteJucate) == 0 {
+		variantaJucata := models.Variant{
+			Numbers:     []models.Number{},
+			WinsRegular: checker.DefaultCategories(game),
+			WinsSpecial: checker.DefaultCategories(game),
+		}
+
+		checkResult.VarianteJucate = append(checkResult.VarianteJucate, variantaJucata)
+		return
+	}
+
+	for i := range checkResult.VarianteJucate {
+		// Reset Castigator flags once before verification
+		for j := range checkResult.VarianteJucate[i].Numbers {
+			checkResult.VarianteJucate[i].Numbers[j].IsWinner = false
+		}
+
+		if isSystematicVariant(checkResult.VarianteJucate[i], game) {
+			checkSystematicVariant(checker, game, &checkResult.VarianteJucate[i], checkResult.DrawResult)
+			continue
+		}
+
+		checker.CheckVariant(&checkResult.VarianteJucate[i], checkResult.DrawResult.VariantRegular, game)
+		checker.CheckVariant(&checkResult.VarianteJucate[i], checkResult.DrawResult.VariantSpecial, game)
+	}
+}
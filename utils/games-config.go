@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"fmt"
+	"loto-suite/backend/models"
+	"os"
+)
+
+func init() {
+	registerRuleGameCheckers(models.Games)
+}
+
+// LoadGamesConfig replaces the built-in game definitions with the ones in the JSON file at path.
+// Every game is validated first, so a broken file leaves the current definitions in place.
+func LoadGamesConfig(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read games config: %w", err)
+	}
+
+	games, err := models.ParseGames(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse games config: %w", err)
+	}
+
+	if len(games) == 0 {
+		return fmt.Errorf("games config %s defines no games", path)
+	}
+
+	gameIds := map[string]bool{}
+	for _, game := range games {
+		if gameIds[game.Id] {
+			return fmt.Errorf("game %s is defined more than once", game.Id)
+		}
+
+		gameIds[game.Id] = true
+
+		if err := validateGameDefinition(game); err != nil {
+			return fmt.Errorf("invalid definition of game %s: %w", game.Id, err)
+		}
+	}
+
+	models.Games = games
+	registerRuleGameCheckers(games)
+
+	return nil
+}
+
+func registerRuleGameCheckers(games []*models.Game) {
+	for _, game := range games {
+		if _, err := GetGameChecker(game.Id); err != nil {
+			RegisterGameChecker(game.Id, ruleGameChecker{})
+		}
+	}
+}
+
+func validateGameDefinition(game *models.Game) error {
+	switch {
+	case game.Id == "":
+		return fmt.Errorf("id is required")
+	case game.Url == "":
+		return fmt.Errorf("url is required")
+	case game.VariantMinNumber < 0 || game.VariantMinNumber >= game.VariantMaxNumber:
+		return fmt.Errorf("invalid number range %d-%d", game.VariantMinNumber, game.VariantMaxNumber)
+	case game.VariantMinNumbersCount <= 0:
+		return fmt.Errorf("min_numere_per_varianta_jucata must be positive")
+	case game.VariantMaxNumbersCount < game.VariantMinNumbersCount:
+		return fmt.Errorf("max_numere_per_varianta_jucata must be at least %d", game.VariantMinNumbersCount)
+	case game.VariantDrawNumbersCount < game.VariantMinNumbersCount:
+		return fmt.Errorf("numere_per_varianta_extrasa must be at least %d", game.VariantMinNumbersCount)
+	case game.VariantsMaxCount <= 0:
+		return fmt.Errorf("numar_max_variante must be positive")
+	case game.ConsecutiveDrawsMaxCount <= 0:
+		return fmt.Errorf("numar_max_extrageri_consecutive must be positive")
+	case game.LuckyNumberDigitCount <= 0:
+		return fmt.Errorf("numar_cifre_noroc must be positive")
+	case len(game.VariantCategories) == 0:
+		return fmt.Errorf("at least one variant prize category is required")
+	case game.Price.Currency == "":
+		return fmt.Errorf("pret: moneda is required")
+	case game.Price.VariantPrice <= 0:
+		return fmt.Errorf("pret: pret_varianta must be positive")
+	case game.Price.LuckyNumberPrice < 0 || game.Price.SpecialDrawVariantPrice < 0:
+		return fmt.Errorf("pret: prices must not be negative")
+	}
+
+	if _, err := getFirstDrawDate(game); err != nil {
+		return err
+	}
+
+	if pool := game.SecondaryPool; pool != nil {
+		switch {
+		case pool.Count <= 0:
+			return fmt.Errorf("pool_secundar: numar_numere must be positive")
+		case pool.MinNumber < 0 || pool.MinNumber > pool.MaxNumber:
+			return fmt.Errorf("pool_secundar: invalid number range %d-%d", pool.MinNumber, pool.MaxNumber)
+		}
+	}
+
+	for _, categorie := range game.VariantCategories {
+		switch {
+		case categorie.Id == "":
+			return fmt.Errorf("variant prize category without id")
+		case categorie.Matches <= 0 || categorie.Matches > game.VariantMinNumbersCount:
+			return fmt.Errorf("category %s: numere_potrivite must be between 1 and %d", categorie.Id, game.VariantMinNumbersCount)
+		case categorie.SecondaryMatches > getSecondaryNumbersCount(game):
+			return fmt.Errorf("category %s: numere_secundare_potrivite must be at most %d", categorie.Id, getSecondaryNumbersCount(game))
+		case categorie.FixedAmount < 0:
+			return fmt.Errorf("category %s: suma_fixa cannot be negative", categorie.Id)
+		}
+	}
+
+	for _, categorie := range game.LuckyNumberCategories {
+		switch {
+		case categorie.Id == "":
+			return fmt.Errorf("lucky number prize category without id")
+		case categorie.FixedAmount < 0:
+			return fmt.Errorf("category %s: suma_fixa cannot be negative", categorie.Id)
+		case categorie.Offset != 0:
+			continue
+		case categorie.Digits <= 0 || categorie.Digits > game.LuckyNumberDigitCount:
+			return fmt.Errorf("category %s: cifre must be between 1 and %d", categorie.Id, game.LuckyNumberDigitCount)
+		case categorie.Match != models.LuckyNumberMatchLast && categorie.Match != models.LuckyNumberMatchFirstOrLast:
+			return fmt.Errorf("category %s: potrivire must be %q or %q", categorie.Id, models.LuckyNumberMatchLast, models.LuckyNumberMatchFirstOrLast)
+		}
+	}
+
+	return nil
+}
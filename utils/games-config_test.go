@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"loto-suite/backend/models"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testGameDefinition = `{
+  "games": [
+    {
+      "id": "test",
+      "display_name": "TEST 5/35",
+      "url": "https://example.org/test",
+      "numar_cifre_noroc": 6,
+      "min_numere_per_varianta_jucata": 5,
+      "max_numere_per_varianta_jucata": 7,
+      "numar_max_variante": 2,
+      "numar_max_extrageri_consecutive": 4,
+      "numere_per_varianta_extrasa": 5,
+      "min_value_numar_varianta": 1,
+      "max_value_numar_varianta": 35,
+      "nume_noroc": "NOROC",
+      %s
+      "categorii_castig_varianta": [
+        { "id": "I (5/5)", "numere_potrivite": 5 },
+        { "id": "II (4/5)", "numere_potrivite": 4 }
+      ]
+    }
+  ]
+}`
+
+func TestLoadGamesConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		price string
+		err   string
+	}{
+		{"with prices", `"pret": { "moneda": "RON", "pret_varianta": 3, "pret_noroc": 2 },`, ""},
+		{"without prices", "", "pret: moneda is required"},
+		{"without variant price", `"pret": { "moneda": "RON", "pret_noroc": 2 },`, "pret: pret_varianta must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			games := models.Games
+			t.Cleanup(func() { models.Games = games })
+
+			path := filepath.Join(t.TempDir(), "games.json")
+			if err := os.WriteFile(path, []byte(strings.Replace(testGameDefinition, "%s", tt.price, 1)), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			err := LoadGamesConfig(path)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected an error containing %q, got %v", tt.err, err)
+				}
+
+				if len(models.Games) != len(games) {
+					t.Errorf("expected the previous games to be kept")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cost, err := CalculateTicketCost(models.CheckRequest{
+				GameId:      "test",
+				LuckyNumber: "123456",
+				Variants:    []models.Variant{{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6), IsSystematic: true}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cost.Lines != 6 || cost.CostPerDraw != 20 {
+				t.Errorf("expected 6 lines for 20 per draw, got %d and %v", cost.Lines, cost.CostPerDraw)
+			}
+		})
+	}
+}
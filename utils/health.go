@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"loto-suite/backend/models"
+	"loto-suite/backend/store"
+	"sort"
+)
+
+// GetHealthStatus reports the months whose results currently fail the scrape quality checks
+// and the state of the upstream circuit breakers.
+func GetHealthStatus() models.HealthStatus {
+	health := models.HealthStatus{
+		Status:          models.HealthStatusOk,
+		ScrapeFailures:  []models.ScrapeFailure{},
+		CircuitBreakers: GetCircuitBreakerStates(),
+		LatestDraws:     []models.DrawAvailability{},
+	}
+
+	// Draw availability is recorded only for the results that go through the store
+	if IsDrawSourceStored() {
+		for _, game := range models.Games {
+			if availability, found := store.GetLatestDrawAvailability(game.Id); found {
+				health.LatestDraws = append(health.LatestDraws, *availability)
+			}
+		}
+	}
+
+	scrapeFailuresMutex.RLock()
+	for _, failure := range scrapeFailures {
+		health.ScrapeFailures = append(health.ScrapeFailures, failure)
+	}
+	scrapeFailuresMutex.RUnlock()
+
+	sort.Slice(health.ScrapeFailures, func(i, j int) bool {
+		return health.ScrapeFailures[i].LastFailAt.After(health.ScrapeFailures[j].LastFailAt)
+	})
+
+	if len(health.ScrapeFailures) > 0 {
+		health.Status = models.HealthStatusDegraded
+	}
+
+	for _, breaker := range health.CircuitBreakers {
+		if breaker.State != models.CircuitClosed {
+			health.Status = models.HealthStatusDegraded
+		}
+	}
+
+	return health
+}
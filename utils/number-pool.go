@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"loto-suite/backend/models"
+)
+
+// normalizeVariant returns a copy of varianta with the secondary pool numbers in SecondaryNumbers.
+// Variants played, drawn or cached in the legacy format carry them as the last Numbers
+// (e.g. the joker after the first set), which is only recognised when SecondaryNumbers is empty.
+func normalizeVariant(varianta models.Variant, game *models.Game) models.Variant {
+	pool := game.SecondaryPool
+	if pool == nil || len(varianta.SecondaryNumbers) > 0 || len(varianta.Numbers) < game.VariantMinNumbersCount+pool.Count {
+		return varianta
+	}
+
+	split := len(varianta.Numbers) - pool.Count
+	varianta.SecondaryNumbers = append([]models.Number{}, varianta.Numbers[split:]...)
+	varianta.Numbers = append([]models.Number{}, varianta.Numbers[:split]...)
+
+	return varianta
+}
+
+func normalizeVariants(variante []models.Variant, game *models.Game) []models.Variant {
+	normalized := make([]models.Variant, 0, len(variante))
+	for _, varianta := range variante {
+		normalized = append(normalized, normalizeVariant(varianta, game))
+	}
+
+	return normalized
+}
+
+func normalizeDrawResult(drawResult *models.DrawResult, game *models.Game) {
+	if drawResult.VariantRegular != nil {
+		normalized := normalizeVariant(*drawResult.VariantRegular, game)
+		drawResult.VariantRegular = &normalized
+	}
+
+	if drawResult.VariantSpecial != nil {
+		normalized := normalizeVariant(*drawResult.VariantSpecial, game)
+		drawResult.VariantSpecial = &normalized
+	}
+}
+
+func normalizeDrawResults(drawResults []models.DrawResult, game *models.Game) {
+	for i := range drawResults {
+		normalizeDrawResult(&drawResults[i], game)
+	}
+}
+
+func getSecondaryNumbersCount(game *models.Game) int {
+	if game.SecondaryPool == nil {
+		return 0
+	}
+
+	return game.SecondaryPool.Count
+}
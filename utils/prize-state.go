@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"loto-suite/backend/generics"
+	"loto-suite/backend/models"
+)
+
+// getPrizeState tells how the amount of a scraped prize category is known. The results page
+// shows "-" both for amounts not published yet and for categories nobody won.
+func getPrizeState(categorie models.WinCategory) models.PrizeState {
+	switch {
+	case categorie.Amount > 0:
+		return models.PrizeStatePaid
+	case categorie.WinnersCount == 0 && categorie.Report > 0:
+		return models.PrizeStateRolledOver
+	default:
+		return models.PrizeStatePending
+	}
+}
+
+func setPrizeStates(categoriiCastig []models.WinCategory) {
+	for i := range categoriiCastig {
+		categoriiCastig[i].State = getPrizeState(categoriiCastig[i])
+	}
+}
+
+// resolveWinCategories returns a copy of the prize categories of a draw where every category has
+// a State, and where the categories with a fixed amount in the game rules are paid that amount
+// when the draw did not publish one.
+func resolveWinCategories(categoriiCastig []models.WinCategory, fixedAmounts []models.WinCategory) []models.WinCategory {
+	resolved := make([]models.WinCategory, 0, len(categoriiCastig))
+	for _, categorie := range categoriiCastig {
+		if categorie.State == "" {
+			categorie.State = getPrizeState(categorie)
+		}
+
+		resolved = append(resolved, categorie)
+	}
+
+	for _, fixed := range fixedAmounts {
+		index := generics.IndexOf(resolved, func(c models.WinCategory) bool {
+			return c.Id == fixed.Id
+		})
+
+		if index == -1 {
+			resolved = append(resolved, models.WinCategory{Id: fixed.Id, Amount: fixed.Amount, State: models.PrizeStateFixed})
+			continue
+		}
+
+		if resolved[index].State != models.PrizeStatePaid {
+			resolved[index].Amount = fixed.Amount
+			resolved[index].State = models.PrizeStateFixed
+		}
+	}
+
+	return resolved
+}
+
+// resolveDrawWinCategories applies resolveWinCategories to every prize table of drawResult.
+func resolveDrawWinCategories(drawResult *models.DrawResult, game *models.Game) {
+	variantFixedAmounts := []models.WinCategory{}
+	for _, categorie := range game.VariantCategories {
+		if categorie.FixedAmount > 0 {
+			variantFixedAmounts = append(variantFixedAmounts, models.WinCategory{Id: categorie.Id, Amount: categorie.FixedAmount})
+		}
+	}
+
+	luckyNumberFixedAmounts := []models.WinCategory{}
+	for _, categorie := range game.LuckyNumberCategories {
+		if categorie.FixedAmount > 0 {
+			luckyNumberFixedAmounts = append(luckyNumberFixedAmounts, models.WinCategory{Id: categorie.Id, Amount: categorie.FixedAmount})
+		}
+	}
+
+	drawResult.WinCategoriesVariantRegular = resolveWinCategories(drawResult.WinCategoriesVariantRegular, variantFixedAmounts)
+	if drawResult.VariantSpecial != nil {
+		drawResult.WinCategoriesVariantSpecial = resolveWinCategories(drawResult.WinCategoriesVariantSpecial, variantFixedAmounts)
+	}
+	drawResult.WinCategoriesLuckyNumber = resolveWinCategories(drawResult.WinCategoriesLuckyNumber, luckyNumberFixedAmounts)
+}
+
+// countPendingWins counts the wins whose amount is not published yet; rolled over and fixed
+// categories are settled and do not count.
+func countPendingWins(castiguriCumulate []models.WinCumulated) int {
+	count := 0
+	for _, castig := range castiguriCumulate {
+		if castig.State == models.PrizeStatePending {
+			count += castig.WinCount
+		}
+	}
+
+	return count
+}
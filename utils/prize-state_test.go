@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"fmt"
+	"loto-suite/backend/models"
+	"testing"
+)
+
+func TestGetPrizeState(t *testing.T) {
+	tests := []struct {
+		name      string
+		categorie models.WinCategory
+		expected  models.PrizeState
+	}{
+		{"published amount", models.WinCategory{WinnersCount: 3, Amount: 1500}, models.PrizeStatePaid},
+		{"nobody won", models.WinCategory{WinnersCount: 0, Report: 250000}, models.PrizeStateRolledOver},
+		{"amount not published yet", models.WinCategory{WinnersCount: 3}, models.PrizeStatePending},
+		{"nothing published", models.WinCategory{}, models.PrizeStatePending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if state := getPrizeState(tt.categorie); state != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, state)
+			}
+		})
+	}
+}
+
+func TestResolveDrawWinCategories(t *testing.T) {
+	game := &models.Game{
+		VariantCategories: []models.VariantCategoryRule{
+			{Id: "I (5/5)"},
+			{Id: "II (4/5)", FixedAmount: 100},
+			{Id: "III (3/5)", FixedAmount: 10},
+		},
+		LuckyNumberCategories: []models.LuckyNumberCategoryRule{
+			{Id: "I"},
+			{Id: "V", FixedAmount: 20},
+		},
+	}
+
+	drawResult := models.DrawResult{
+		WinCategoriesVariantRegular: []models.WinCategory{
+			{Id: "I (5/5)", WinnersCount: 0, Report: 500000},
+			{Id: "II (4/5)", WinnersCount: 12, Amount: 150},
+			{Id: "III (3/5)", WinnersCount: 400},
+		},
+		WinCategoriesLuckyNumber: []models.WinCategory{
+			{Id: "I", WinnersCount: 1},
+		},
+	}
+
+	resolveDrawWinCategories(&drawResult, game)
+
+	expected := map[string][]string{
+		"regular": {"I (5/5) rolled_over 0", "II (4/5) paid 150", "III (3/5) fixed 10"},
+		"noroc":   {"I pending 0", "V fixed 20"},
+	}
+
+	actual := map[string][]string{
+		"regular": describeWinCategories(drawResult.WinCategoriesVariantRegular),
+		"noroc":   describeWinCategories(drawResult.WinCategoriesLuckyNumber),
+	}
+
+	for table := range expected {
+		if fmt.Sprint(actual[table]) != fmt.Sprint(expected[table]) {
+			t.Errorf("%s: expected %v, got %v", table, expected[table], actual[table])
+		}
+	}
+
+	if drawResult.WinCategoriesVariantSpecial != nil {
+		t.Errorf("expected no special categories without a special draw, got %v", drawResult.WinCategoriesVariantSpecial)
+	}
+
+	details := getWinDetails([]models.Win{{Id: "V", IsWinner: true}}, drawResult.WinCategoriesLuckyNumber)
+	if len(details) != 1 || details[0].State != models.PrizeStateFixed || details[0].Amount != 20 {
+		t.Errorf("expected the fixed amount in the breakdown, got %+v", details)
+	}
+}
+
+func TestCountPendingWins(t *testing.T) {
+	castiguri := []models.WinCumulated{
+		{Id: "I", WinCount: 1, State: models.PrizeStateRolledOver},
+		{Id: "II", WinCount: 2, State: models.PrizeStatePending},
+		{Id: "III", WinCount: 3, State: models.PrizeStatePaid, Amount: 100},
+		{Id: "IV", WinCount: 4, State: models.PrizeStateFixed, Amount: 10},
+	}
+
+	if count := countPendingWins(castiguri); count != 2 {
+		t.Errorf("expected 2 pending wins, got %d", count)
+	}
+}
+
+func describeWinCategories(categoriiCastig []models.WinCategory) []string {
+	described := []string{}
+	for _, categorie := range categoriiCastig {
+		described = append(described, fmt.Sprintf("%s %s %v", categorie.Id, categorie.State, categorie.Amount))
+	}
+
+	return described
+}
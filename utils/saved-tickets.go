@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"loto-suite/backend/generics"
+	"loto-suite/backend/logging"
+	"loto-suite/backend/models"
+	"loto-suite/backend/tickets"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// drawHour is the local hour after which the results of a draw day can be published.
+const drawHour = 21
+
+// savedTicketResultsDeadline is how long after a draw the watcher keeps looking for its results
+// before the ticket is marked failed, so a draw that is never published is not scraped forever.
+const savedTicketResultsDeadline = 7 * 24 * time.Hour
+
+// SaveTicket registers a ticket for an upcoming draw date. The ticket watcher checks it once
+// the results of all its draws are published; tickets for draws already held are rejected.
+func SaveTicket(request models.CheckRequest) (*models.SavedTicket, error) {
+	ticket, err := newTicketCheck(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isDrawDay(ticket.date) {
+		return nil, &TicketValidationError{Errors: []models.ValidationError{{
+			Field:   "date",
+			Code:    models.ValidationCodeNotADrawDay,
+			Message: fmt.Sprintf("there is no draw on %s", ticket.date.Format(generics.DateDisplayFormat)),
+		}}}
+	}
+
+	if !time.Now().Before(getDrawTime(ticket.date)) {
+		return nil, &TicketValidationError{Errors: []models.ValidationError{{
+			Field:   "date",
+			Code:    models.ValidationCodeDrawAlreadyHeld,
+			Message: fmt.Sprintf("the draw on %s has already taken place, check the ticket instead", ticket.date.Format(generics.DateDisplayFormat)),
+		}}}
+	}
+
+	savedTicket := models.SavedTicket{
+		Id:         uuid.New().String(),
+		Request:    ticket.request,
+		State:      models.SavedTicketStatePending,
+		CreatedAt:  time.Now(),
+		NextDrawAt: getDrawTime(ticket.date),
+	}
+
+	if err := tickets.Save(savedTicket); err != nil {
+		logging.Error("tickets", err, "")
+		return nil, fmt.Errorf("failed to save ticket: %w", err)
+	}
+
+	return &savedTicket, nil
+}
+
+// StartTicketWatcher checks the pending saved tickets every interval, in the background.
+func StartTicketWatcher(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			CheckPendingTickets(time.Now())
+			<-ticker.C
+		}
+	}()
+}
+
+// CheckPendingTickets checks every pending ticket whose next draw took place before now.
+// Months cached before the draw was published are scraped again, once per run.
+func CheckPendingTickets(now time.Time) {
+	resolver := newDrawResultsResolver()
+	refreshed := map[string]bool{}
+
+	for _, savedTicket := range tickets.List(models.SavedTicketStatePending) {
+		if now.Before(savedTicket.NextDrawAt) {
+			continue
+		}
+
+		checkSavedTicket(&savedTicket, resolver, refreshed, now)
+
+		if err := tickets.Save(savedTicket); err != nil {
+			logging.Error("tickets", err, "")
+		}
+	}
+}
+
+func checkSavedTicket(savedTicket *models.SavedTicket, resolver *drawResultsResolver, refreshed map[string]bool, now time.Time) {
+	savedTicket.LastAttemptAt = &now
+	savedTicket.Error = ""
+
+	request := savedTicket.Request
+	gameId := request.GameId
+
+	for _, drawDate := range GetNextDrawDates(getDrawDay(savedTicket.NextDrawAt), GetDrawCount(request)) {
+		if now.Before(getDrawTime(drawDate)) {
+			break
+		}
+
+		key := fmt.Sprintf("%s_%d_%d", gameId, drawDate.Month(), drawDate.Year())
+		if _, err := resolver.find(gameId, drawDate); err != nil && !refreshed[key] {
+			refreshed[key] = true
+			if err := resolver.refresh(gameId, drawDate); err != nil {
+				logging.Error("tickets", err, "")
+			}
+		}
+	}
+
+	if GetDrawCount(request) > 1 {
+		result, err := checkTicketMultiDraw(request, resolver)
+		if err != nil {
+			setSavedTicketError(savedTicket, err)
+			checkSavedTicketDeadline(savedTicket, now)
+			return
+		}
+
+		savedTicket.MultiDrawResult = result
+		if result.DrawsPending > 0 {
+			savedTicket.NextDrawAt = getDrawTime(getFirstPendingDrawDate(result))
+			checkSavedTicketDeadline(savedTicket, now)
+			return
+		}
+	} else {
+		result, err := checkTicket(request, resolver)
+		if err != nil {
+			setSavedTicketError(savedTicket, err)
+			checkSavedTicketDeadline(savedTicket, now)
+			return
+		}
+
+		savedTicket.Result = result
+	}
+
+	savedTicket.State = models.SavedTicketStateChecked
+	savedTicket.CheckedAt = &now
+}
+
+// checkSavedTicketDeadline marks a ticket that is still pending failed once the results of its
+// next draw are overdue by more than savedTicketResultsDeadline.
+func checkSavedTicketDeadline(savedTicket *models.SavedTicket, now time.Time) {
+	if savedTicket.State != models.SavedTicketStatePending || now.Sub(savedTicket.NextDrawAt) <= savedTicketResultsDeadline {
+		return
+	}
+
+	err := fmt.Errorf("the results of the draw on %s were not published within %d days",
+		savedTicket.NextDrawAt.Format(generics.DateDisplayFormat), int(savedTicketResultsDeadline.Hours()/24))
+	if savedTicket.Error != "" {
+		err = fmt.Errorf("%v: %s", err, savedTicket.Error)
+	}
+
+	logging.Error("tickets", fmt.Errorf("ticket %s cannot be checked: %w", savedTicket.Id, err), "")
+	savedTicket.State = models.SavedTicketStateFailed
+	savedTicket.Error = err.Error()
+}
+
+// setSavedTicketError records why a ticket could not be checked. Results that are not published
+// yet are tried again on the next run, while a ticket the game rules reject never will be.
+func setSavedTicketError(savedTicket *models.SavedTicket, err error) {
+	savedTicket.Error = err.Error()
+
+	var validationErr *TicketValidationError
+	if errors.As(err, &validationErr) || errors.Is(err, ErrUnsupportedGame) {
+		logging.Error("tickets", fmt.Errorf("ticket %s cannot be checked: %w", savedTicket.Id, err), "")
+		savedTicket.State = models.SavedTicketStateFailed
+	}
+}
+
+func getFirstPendingDrawDate(result *models.MultiDrawCheckResult) time.Time {
+	for _, draw := range result.Draws {
+		if draw.Result == nil {
+			drawDate, _ := generics.TryParseDate(draw.Date)
+			return drawDate
+		}
+	}
+
+	return time.Time{}
+}
+
+func isDrawDay(date time.Time) bool {
+	_, isDrawDay := generics.DrawDays[int(date.Weekday())]
+	return isDrawDay
+}
+
+func getDrawTime(drawDate time.Time) time.Time {
+	return time.Date(drawDate.Year(), drawDate.Month(), drawDate.Day(), drawHour, 0, 0, 0, time.Local)
+}
+
+func getDrawDay(drawTime time.Time) time.Time {
+	drawDate, _ := generics.TryParseDate(drawTime.Format(generics.GoDateFormat))
+	return drawDate
+}
@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"loto-suite/backend/generics"
+	"loto-suite/backend/models"
+	"testing"
+	"time"
+)
+
+func TestCheckSavedTicket(t *testing.T) {
+	published := newTestDraw("2024-03-28", 1, 2, 3, 10, 11, 12)
+
+	tests := []struct {
+		name       string
+		request    models.CheckRequest
+		drawn      []models.DrawResult
+		sourceErr  error
+		now        string
+		state      string
+		nextDrawAt string
+		hasError   bool
+	}{
+		{
+			name:       "results published",
+			request:    newTestRequest("2024-03-28", 1, 2, 3, 4, 5, 6),
+			drawn:      []models.DrawResult{published},
+			now:        "2024-03-28T22:00:00",
+			state:      models.SavedTicketStateChecked,
+			nextDrawAt: "2024-03-28",
+		},
+		{
+			name:       "results not published yet",
+			request:    newTestRequest("2024-03-28", 1, 2, 3, 4, 5, 6),
+			now:        "2024-03-29T10:00:00",
+			state:      models.SavedTicketStatePending,
+			nextDrawAt: "2024-03-28",
+			hasError:   true,
+		},
+		{
+			name:       "results never published",
+			request:    newTestRequest("2024-03-28", 1, 2, 3, 4, 5, 6),
+			now:        "2024-04-05T22:00:00",
+			state:      models.SavedTicketStateFailed,
+			nextDrawAt: "2024-03-28",
+			hasError:   true,
+		},
+		{
+			name:       "upstream failure",
+			request:    newTestRequest("2024-03-28", 1, 2, 3, 4, 5, 6),
+			sourceErr:  ErrCircuitOpen,
+			now:        "2024-03-28T22:00:00",
+			state:      models.SavedTicketStatePending,
+			nextDrawAt: "2024-03-28",
+			hasError:   true,
+		},
+		{
+			name:       "next of several draws not published yet",
+			request:    models.CheckRequest{GameId: "649", Date: "2024-03-28", DrawCount: 2, Variants: newTestRequest("", 1, 2, 3, 4, 5, 6).Variants},
+			drawn:      []models.DrawResult{published},
+			now:        "2024-04-01T10:00:00",
+			state:      models.SavedTicketStatePending,
+			nextDrawAt: "2024-03-31",
+		},
+		{
+			name:       "next of several draws never published",
+			request:    models.CheckRequest{GameId: "649", Date: "2024-03-28", DrawCount: 2, Variants: newTestRequest("", 1, 2, 3, 4, 5, 6).Variants},
+			drawn:      []models.DrawResult{published},
+			now:        "2024-04-10T10:00:00",
+			state:      models.SavedTicketStateFailed,
+			nextDrawAt: "2024-03-31",
+			hasError:   true,
+		},
+		{
+			name:       "game no longer supported",
+			request:    models.CheckRequest{GameId: "keno", Date: "2024-03-28", Variants: newTestRequest("", 1, 2, 3, 4, 5, 6).Variants},
+			now:        "2024-03-28T22:00:00",
+			state:      models.SavedTicketStateFailed,
+			nextDrawAt: "2024-03-28",
+			hasError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := newFakeDrawSource(tt.drawn...)
+			if tt.sourceErr != nil {
+				source.errs["649_3_2024"] = tt.sourceErr
+			}
+			useDrawSource(t, source)
+
+			now, err := time.ParseInLocation("2006-01-02T15:04:05", tt.now, time.Local)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			drawDate, _ := generics.TryParseDate(tt.request.Date)
+			savedTicket := models.SavedTicket{
+				Id:         "test",
+				Request:    tt.request,
+				State:      models.SavedTicketStatePending,
+				NextDrawAt: getDrawTime(drawDate),
+			}
+
+			checkSavedTicket(&savedTicket, newDrawResultsResolver(), map[string]bool{}, now)
+
+			if savedTicket.State != tt.state {
+				t.Errorf("expected state %s, got %s (%s)", tt.state, savedTicket.State, savedTicket.Error)
+			}
+
+			if nextDrawAt := savedTicket.NextDrawAt.Format(generics.GoDateFormat); nextDrawAt != tt.nextDrawAt {
+				t.Errorf("expected the next draw on %s, got %s", tt.nextDrawAt, nextDrawAt)
+			}
+
+			if (savedTicket.Error != "") != tt.hasError {
+				t.Errorf("expected an error %v, got %q", tt.hasError, savedTicket.Error)
+			}
+
+			if (savedTicket.CheckedAt != nil) != (tt.state == models.SavedTicketStateChecked) {
+				t.Errorf("expected verificat_la only once checked, got %v", savedTicket.CheckedAt)
+			}
+
+			if savedTicket.LastAttemptAt == nil || !savedTicket.LastAttemptAt.Equal(now) {
+				t.Errorf("expected the attempt at %v, got %v", now, savedTicket.LastAttemptAt)
+			}
+		})
+	}
+}
+
+func TestCheckSavedTicketRefreshesOncePerRun(t *testing.T) {
+	source := newFakeDrawSource()
+	useDrawSource(t, source)
+
+	now := time.Date(2024, 3, 29, 10, 0, 0, 0, time.Local)
+	resolver := newDrawResultsResolver()
+	refreshed := map[string]bool{}
+
+	for i := 0; i < 3; i++ {
+		savedTicket := models.SavedTicket{
+			Id:         fmt.Sprintf("test-%d", i),
+			Request:    newTestRequest("2024-03-28", 1, 2, 3, 4, 5, 6),
+			State:      models.SavedTicketStatePending,
+			NextDrawAt: getDrawTime(time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC)),
+		}
+
+		checkSavedTicket(&savedTicket, resolver, refreshed, now)
+	}
+
+	if calls := source.calls["649_3_2024"]; calls != 2 {
+		t.Errorf("expected the month to be fetched and refreshed once, got %d requests", calls)
+	}
+}
+
+func TestSaveTicketRejectsPastAndMissingDraws(t *testing.T) {
+	tests := []struct {
+		name string
+		date string
+		code string
+	}{
+		{"not a draw day", "2099-01-05", models.ValidationCodeNotADrawDay},
+		{"draw already held", "2024-03-28", models.ValidationCodeDrawAlreadyHeld},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SaveTicket(newTestRequest(tt.date, 1, 2, 3, 4, 5, 6))
+
+			var validationErr *TicketValidationError
+			if !errors.As(err, &validationErr) || len(validationErr.Errors) != 1 || validationErr.Errors[0].Code != tt.code {
+				t.Errorf("expected %s, got %v", tt.code, err)
+			}
+		})
+	}
+}
@@ -47,6 +47,10 @@ func ScanareBilet(gameId string, imageData []byte) (*models.ScanResult, error) {
 
 	// logging.InfoBe(fmt.Sprintf("Parsed result: game_id=%s, variants=%d", result.GameId, len(result.Variante)))
 
+	if game, err := GetGameById(gameId); err == nil {
+		result.Variants = normalizeVariants(result.Variants, game)
+	}
+
 	return &result, nil
 }
 
@@ -0,0 +1,206 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"loto-suite/backend/generics"
+	"loto-suite/backend/logging"
+	"loto-suite/backend/models"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrScrapeLayout is wrapped by the errors of scraped results that fail the quality checks,
+// which usually means loto.ro changed the markup of its results page.
+var ErrScrapeLayout = errors.New("scrape layout check failed")
+
+type ScrapeValidationError struct {
+	GameId   string
+	Month    string
+	Year     string
+	Problems []string
+}
+
+func (e *ScrapeValidationError) Error() string {
+	return fmt.Sprintf("%s for %s %s/%s: %s", ErrScrapeLayout, e.GameId, e.Month, e.Year, strings.Join(e.Problems, "; "))
+}
+
+func (e *ScrapeValidationError) Unwrap() error {
+	return ErrScrapeLayout
+}
+
+var (
+	scrapeFailures      = map[string]models.ScrapeFailure{}
+	scrapeFailuresMutex sync.RWMutex
+)
+
+// validateScrapedDrawResults checks the parsed results of a month against the game rules.
+// skippedBlocks is the number of result blocks of the page that could not be parsed at all.
+func validateScrapedDrawResults(game *models.Game, month string, year string, drawResults []models.DrawResult, skippedBlocks int) error {
+	problems := []string{}
+
+	if skippedBlocks > 0 {
+		problems = append(problems, fmt.Sprintf("%d result block(s) without a draw date", skippedBlocks))
+	}
+
+	if len(drawResults) == 0 && isDrawExpected(game, month, year, time.Now()) {
+		problems = append(problems, "no draws found")
+	}
+
+	// The prize tables of the latest draw are published a while after it, until then they are pending
+	latestDrawDate, _ := getLatestDrawDate(time.Now())
+	for _, drawResult := range drawResults {
+		drawDate, err := generics.TryParseDate(drawResult.GameDate)
+		prizesPending := err == nil && drawDate.Equal(latestDrawDate)
+		problems = append(problems, getDrawResultProblems(game, drawResult, prizesPending)...)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return &ScrapeValidationError{
+		GameId:   game.Id,
+		Month:    month,
+		Year:     year,
+		Problems: problems,
+	}
+}
+
+// getDrawResultProblems checks a scraped draw against the game rules. Missing prize tables are
+// only a problem when the prizes of the draw are no longer pending.
+func getDrawResultProblems(game *models.Game, drawResult models.DrawResult, prizesPending bool) []string {
+	problems := []string{}
+	addProblem := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf("%s: %s", drawResult.GameDate, fmt.Sprintf(format, args...)))
+	}
+
+	for _, problem := range getDrawNumbersProblems(game, drawResult) {
+		addProblem("%s", problem)
+	}
+
+	if prizesPending {
+		return problems
+	}
+
+	if len(drawResult.WinCategoriesVariantRegular) == 0 {
+		addProblem("no prize table for the variant")
+	}
+
+	if drawResult.VariantSpecial != nil && len(drawResult.WinCategoriesVariantSpecial) == 0 {
+		addProblem("no prize table for the special draw")
+	}
+
+	if len(drawResult.WinCategoriesLuckyNumber) == 0 {
+		addProblem("no prize table for %s", game.LuckyNumberName)
+	}
+
+	return problems
+}
+
+// getDrawNumbersProblems checks the drawn numbers and the lucky number of a draw against the game rules.
+func getDrawNumbersProblems(game *models.Game, drawResult models.DrawResult) []string {
+	problems := []string{}
+	addProblem := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	variants := []*models.Variant{drawResult.VariantRegular}
+	if drawResult.VariantSpecial != nil {
+		variants = append(variants, drawResult.VariantSpecial)
+	}
+
+	for _, varianta := range variants {
+		if varianta == nil {
+			addProblem("no drawn numbers")
+			continue
+		}
+
+		if len(varianta.Numbers) != game.VariantDrawNumbersCount {
+			addProblem("%d drawn numbers instead of %d", len(varianta.Numbers), game.VariantDrawNumbersCount)
+		}
+
+		for i, numar := range varianta.Numbers {
+			if numar.Value < game.VariantMinNumber || numar.Value > game.VariantMaxNumber {
+				addProblem("drawn number %d is not between %d and %d", numar.Value, game.VariantMinNumber, game.VariantMaxNumber)
+			}
+
+			if ContainsNumarByValue(varianta.Numbers[:i], numar) {
+				addProblem("drawn number %d appears more than once", numar.Value)
+			}
+		}
+
+		if pool := game.SecondaryPool; pool != nil {
+			if len(varianta.SecondaryNumbers) != pool.Count {
+				addProblem("%d %s number(s) instead of %d", len(varianta.SecondaryNumbers), pool.Name, pool.Count)
+			}
+
+			for _, numar := range varianta.SecondaryNumbers {
+				if numar.Value < pool.MinNumber || numar.Value > pool.MaxNumber {
+					addProblem("%s number %d is not between %d and %d", pool.Name, numar.Value, pool.MinNumber, pool.MaxNumber)
+				}
+			}
+		}
+	}
+
+	if drawResult.LuckyNumber == nil || len(drawResult.LuckyNumber.Value) != game.LuckyNumberDigitCount {
+		addProblem("%s number does not have %d digits", game.LuckyNumberName, game.LuckyNumberDigitCount)
+	} else if _, err := strconv.Atoi(drawResult.LuckyNumber.Value); err != nil {
+		addProblem("%s number %q is not numeric", game.LuckyNumberName, drawResult.LuckyNumber.Value)
+	}
+
+	return problems
+}
+
+// isDrawExpected reports whether the results page of a month must list at least one draw: the
+// month has a draw day whose results are published by now, and it is not older than the first
+// draw of the game. Without a known first draw only the last two months are certain to have draws.
+func isDrawExpected(game *models.Game, month string, year string, now time.Time) bool {
+	monthInt, err1 := strconv.Atoi(month)
+	yearInt, err2 := strconv.Atoi(year)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+
+	monthStart := time.Date(yearInt, time.Month(monthInt), 1, 0, 0, 0, 0, time.UTC)
+
+	if firstDrawDate, err := getFirstDrawDate(game); err != nil || firstDrawDate.IsZero() {
+		if monthStart.Before(getMonthStart(now).AddDate(0, -1, 0)) {
+			return false
+		}
+	} else if monthStart.AddDate(0, 1, 0).Before(firstDrawDate) {
+		return false
+	}
+
+	// The results of a draw are expected the day after it, once they are surely published
+	firstDrawDate := GetNextDrawDates(monthStart, 1)[0]
+	return firstDrawDate.Month() == monthStart.Month() && now.After(getDrawTime(firstDrawDate).AddDate(0, 0, 1))
+}
+
+func recordScrapeFailure(err *ScrapeValidationError) {
+	logging.Error("scrape-layout", err, "")
+
+	scrapeFailuresMutex.Lock()
+	defer scrapeFailuresMutex.Unlock()
+
+	key := fmt.Sprintf("%s_%s_%s", err.GameId, err.Month, err.Year)
+	failure := scrapeFailures[key]
+
+	scrapeFailures[key] = models.ScrapeFailure{
+		GameId:     err.GameId,
+		Month:      err.Month,
+		Year:       err.Year,
+		Problems:   err.Problems,
+		Count:      failure.Count + 1,
+		LastFailAt: time.Now(),
+	}
+}
+
+func clearScrapeFailure(gameId string, month string, year string) {
+	scrapeFailuresMutex.Lock()
+	defer scrapeFailuresMutex.Unlock()
+
+	delete(scrapeFailures, fmt.Sprintf("%s_%s_%s", gameId, month, year))
+}
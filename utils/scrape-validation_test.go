@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"loto-suite/backend/models"
+	"testing"
+	"time"
+)
+
+func TestGetDrawResultProblems(t *testing.T) {
+	tests := []struct {
+		name          string
+		gameId        string
+		edit          func(drawResult *models.DrawResult)
+		prizesPending bool
+		expected      []string
+	}{
+		{
+			name:     "valid draw",
+			gameId:   "649",
+			edit:     func(drawResult *models.DrawResult) {},
+			expected: []string{},
+		},
+		{
+			name:   "numbers against the game rules",
+			gameId: "649",
+			edit: func(drawResult *models.DrawResult) {
+				drawResult.VariantRegular.Numbers = newTestNumbers(1, 1, 50, 4, 5)
+			},
+			expected: []string{
+				"2024-03-28: 5 drawn numbers instead of 6",
+				"2024-03-28: drawn number 1 appears more than once",
+				"2024-03-28: drawn number 50 is not between 1 and 49",
+			},
+		},
+		{
+			name:   "no drawn numbers",
+			gameId: "649",
+			edit: func(drawResult *models.DrawResult) {
+				drawResult.VariantRegular = nil
+			},
+			expected: []string{"2024-03-28: no drawn numbers"},
+		},
+		{
+			name:   "lucky number",
+			gameId: "649",
+			edit: func(drawResult *models.DrawResult) {
+				drawResult.LuckyNumber = &models.LuckyNumber{Value: "04829a5"}
+			},
+			expected: []string{`2024-03-28: NOROC number "04829a5" is not numeric`},
+		},
+		{
+			name:   "missing prize tables",
+			gameId: "649",
+			edit: func(drawResult *models.DrawResult) {
+				drawResult.WinCategoriesVariantRegular = nil
+				drawResult.WinCategoriesLuckyNumber = nil
+			},
+			expected: []string{"2024-03-28: no prize table for the variant", "2024-03-28: no prize table for NOROC"},
+		},
+		{
+			name:   "missing prize tables of the latest draw",
+			gameId: "649",
+			edit: func(drawResult *models.DrawResult) {
+				drawResult.WinCategoriesVariantRegular = nil
+				drawResult.WinCategoriesLuckyNumber = nil
+			},
+			prizesPending: true,
+			expected:      []string{},
+		},
+		{
+			name:   "joker out of its range",
+			gameId: "joker",
+			edit: func(drawResult *models.DrawResult) {
+				drawResult.GameId = "joker"
+				drawResult.LuckyNumber = &models.LuckyNumber{Value: "482915"}
+				drawResult.VariantRegular.Numbers = newTestNumbers(1, 2, 3, 4, 5)
+				drawResult.VariantRegular.SecondaryNumbers = newTestNumbers(21)
+			},
+			expected: []string{"2024-03-28: JOKER number 21 is not between 1 and 20"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := GetGameById(tt.gameId)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			drawResult := newTestDraw("2024-03-28", 1, 2, 3, 4, 5, 6)
+			tt.edit(&drawResult)
+
+			if problems := getDrawResultProblems(game, drawResult, tt.prizesPending); fmt.Sprint(problems) != fmt.Sprint(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, problems)
+			}
+		})
+	}
+}
+
+func TestIsDrawExpected(t *testing.T) {
+	now := time.Date(2024, 4, 10, 12, 0, 0, 0, time.Local)
+	game649, _ := GetGameById("649")
+	gameWithoutFirstDraw := &models.Game{Id: "test"}
+
+	tests := []struct {
+		name     string
+		game     *models.Game
+		month    string
+		year     string
+		expected bool
+	}{
+		{"closed month", game649, "3", "2024", true},
+		{"current month after its first draw", game649, "4", "2024", true},
+		{"next month", game649, "5", "2024", false},
+		{"before the first draw", game649, "11", "1992", false},
+		{"month of the first draw", game649, "1", "1993", true},
+		{"old month without a first draw", gameWithoutFirstDraw, "1", "2020", false},
+		{"previous month without a first draw", gameWithoutFirstDraw, "3", "2024", true},
+		{"invalid month", game649, "march", "2024", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if expected := isDrawExpected(tt.game, tt.month, tt.year, now); expected != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, expected)
+			}
+		})
+	}
+}
+
+func TestValidateScrapedDrawResults(t *testing.T) {
+	game, _ := GetGameById("649")
+
+	if err := validateScrapedDrawResults(game, "3", "2024", []models.DrawResult{newTestDraw("2024-03-28", 1, 2, 3, 4, 5, 6)}, 0); err != nil {
+		t.Errorf("expected a valid month, got %v", err)
+	}
+
+	err := validateScrapedDrawResults(game, "3", "2024", []models.DrawResult{}, 2)
+	if !errors.Is(err, ErrScrapeLayout) {
+		t.Fatalf("expected %v, got %v", ErrScrapeLayout, err)
+	}
+
+	var validationErr *ScrapeValidationError
+	if !errors.As(err, &validationErr) || fmt.Sprint(validationErr.Problems) != "[2 result block(s) without a draw date no draws found]" {
+		t.Errorf("expected the skipped blocks and the missing draws, got %v", err)
+	}
+}
+
+func TestGetHealthStatus(t *testing.T) {
+	useDrawSource(t, newFakeDrawSource())
+
+	if health := GetHealthStatus(); health.Status != models.HealthStatusOk || len(health.LatestDraws) != 0 {
+		t.Errorf("expected a healthy status without draw availability, got %+v", health)
+	}
+
+	recordScrapeFailure(&ScrapeValidationError{GameId: "649", Month: "3", Year: "2024", Problems: []string{"no draws found"}})
+	t.Cleanup(func() { clearScrapeFailure("649", "3", "2024") })
+
+	health := GetHealthStatus()
+	if health.Status != models.HealthStatusDegraded || len(health.ScrapeFailures) != 1 || health.ScrapeFailures[0].Count != 1 {
+		t.Errorf("expected the scrape failure to degrade the status, got %+v", health)
+	}
+}
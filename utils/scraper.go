@@ -2,9 +2,9 @@ package utils
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"loto-suite/backend/cache"
+	"io"
 	"loto-suite/backend/generics"
 	"loto-suite/backend/logging"
 	"loto-suite/backend/models"
@@ -18,30 +18,55 @@ import (
 )
 
 func scrapeDrawResults(game *models.Game, month string, year string) ([]models.DrawResult, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), upstream.getTimeout())
 	defer cancel()
 
 	form := url.Values{}
 	form.Set("select-year", year)
 	form.Set("select-month", month)
 
-	resp, err := doHttpRequest(ctx, "POST", game.Url, nil, form)
+	// The results page is a search form, so the POST is safe to retry
+	resp, err := doUpstreamRequest(ctx, "POST", game.Url, nil, form)
 	if err != nil {
 		logging.Error("scrape", err, "")
-		fmt.Println(err)
 		return nil, err
 	}
 
 	defer resp.Body.Close()
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	return parseResultsPage(game, month, year, resp.Body)
+}
+
+// parseResultsPage parses a results page of loto.ro for a month and checks the parsed results,
+// to notice when the layout of the page changes.
+func parseResultsPage(game *models.Game, month string, year string, page io.Reader) ([]models.DrawResult, error) {
+	doc, err := goquery.NewDocumentFromReader(page)
 	if err != nil {
 		logging.Error("scrape", err, "")
-		fmt.Println(err)
 		return nil, fmt.Errorf("failed to parse HTML: %v", err)
 	}
 
-	var drawResults []models.DrawResult
+	drawResults, skippedBlocks := parseDrawResultsDocument(game, doc)
+
+	if err := validateScrapedDrawResults(game, month, year, drawResults, skippedBlocks); err != nil {
+		var validationErr *ScrapeValidationError
+		if errors.As(err, &validationErr) {
+			recordScrapeFailure(validationErr)
+		}
+
+		return nil, err
+	}
+
+	clearScrapeFailure(game.Id, month, year)
+
+	return drawResults, nil
+}
+
+// parseDrawResultsDocument extracts the draws of a results page. It also returns how many
+// result blocks were skipped because their draw date could not be read.
+func parseDrawResultsDocument(game *models.Game, doc *goquery.Document) ([]models.DrawResult, int) {
+	drawResults := []models.DrawResult{}
+	skippedBlocks := 0
 
 	divVarianteNormale := doc.Find(".rezultate-extrageri-content.resultDiv").Not(".floatright").Not(".resultspecialDiv")
 	divVarianteNormale.Each(func(i int, div *goquery.Selection) {
@@ -50,6 +75,7 @@ func scrapeDrawResults(game *models.Game, month string, year string) ([]models.D
 
 		if err != nil {
 			logging.Error("scrape", err, "")
+			skippedBlocks++
 			return
 		}
 
@@ -77,14 +103,24 @@ func scrapeDrawResults(game *models.Game, month string, year string) ([]models.D
 			}
 		}
 
+		normalizeDrawResult(&gameResult, game)
+		completeDrawResult(&gameResult)
+
 		drawResults = append(drawResults, gameResult)
 	})
 
-	if data, err := json.Marshal(drawResults); err == nil {
-		cache.Set(game.Id, month, year, data, 30*time.Minute)
-	}
+	return drawResults, skippedBlocks
+}
 
-	return drawResults, err
+// completeDrawResult sets the fields derived from the prize tables: the prize states and the jackpots.
+func completeDrawResult(drawResult *models.DrawResult) {
+	setPrizeStates(drawResult.WinCategoriesVariantRegular)
+	setPrizeStates(drawResult.WinCategoriesVariantSpecial)
+	setPrizeStates(drawResult.WinCategoriesLuckyNumber)
+
+	drawResult.JackpotVariantRegular = getJackpot(drawResult.WinCategoriesVariantRegular)
+	drawResult.JackpotVariantSpecial = getJackpot(drawResult.WinCategoriesVariantSpecial)
+	drawResult.JackpotLuckyNumber = getJackpot(drawResult.WinCategoriesLuckyNumber)
 }
 
 func extractNumereVarianta(div *goquery.Selection) []models.Number {
@@ -116,15 +152,7 @@ func extractCategoriiCastigVariante(div *goquery.Selection) []models.WinCategory
 		return categoriiCastig
 	}
 
-	var valoareCastigHeaderColumnIndex int = -1
-	table.Find("thead tr th").Each(func(i int, th *goquery.Selection) {
-		headerText := strings.TrimSpace(th.Text())
-		if strings.Contains(strings.ToLower(headerText), "valoare castig") {
-			valoareCastigHeaderColumnIndex = i
-			return
-		}
-	})
-
+	valoareCastigHeaderColumnIndex := getHeaderColumnIndex(table, "valoare castig")
 	if valoareCastigHeaderColumnIndex == -1 {
 		valoareCastigHeaderColumnIndex = 2
 	}
@@ -132,17 +160,9 @@ func extractCategoriiCastigVariante(div *goquery.Selection) []models.WinCategory
 	table.Find("tbody tr").Each(func(i int, tr *goquery.Selection) {
 		tds := tr.Find("td")
 		if tds.Length() > valoareCastigHeaderColumnIndex+1 {
-			valoareStr := strings.TrimSpace(tds.Eq(valoareCastigHeaderColumnIndex).Text())
-			if valoareStr == "-" || valoareStr == "" {
-				valoareStr = "0"
-			}
-
-			valoare, err := strToEnglishFloat(valoareStr)
+			categorie, err := extractCategorieCastig(tds, valoareCastigHeaderColumnIndex-1, valoareCastigHeaderColumnIndex, valoareCastigHeaderColumnIndex+1)
 			if err == nil {
-				categoriiCastig = append(categoriiCastig, models.WinCategory{
-					Id:     strings.TrimSpace(tds.Eq(0).Text()),
-					Amount: valoare,
-				})
+				categoriiCastig = append(categoriiCastig, categorie)
 			}
 		}
 	})
@@ -159,14 +179,7 @@ func extractCategoriiCastigNoroc(div *goquery.Selection, gameId string) []models
 		return categoriiCastig
 	}
 
-	var valoareCastigHeaderColumnIndex int = -1
-	table.Find("thead tr th").Each(func(i int, th *goquery.Selection) {
-		headerText := strings.TrimSpace(th.Text())
-		if strings.Contains(strings.ToLower(headerText), "valoare castig") {
-			valoareCastigHeaderColumnIndex = i
-			return
-		}
-	})
+	valoareCastigHeaderColumnIndex := getHeaderColumnIndex(table, "valoare castig")
 
 	table.Find("tbody tr").Each(func(i int, tr *goquery.Selection) {
 		tds := tr.Find("td")
@@ -185,23 +198,79 @@ func extractCategoriiCastigNoroc(div *goquery.Selection, gameId string) []models
 			return
 		}
 
-		valoareStr := strings.TrimSpace(tds.Eq(valoareCastigTdIndex).Text())
-		if valoareStr == "-" || valoareStr == "" {
-			valoareStr = "0"
-		}
-
-		valoare, err := strToEnglishFloat(valoareStr)
+		categorie, err := extractCategorieCastig(tds, valoareCastigTdIndex-1, valoareCastigTdIndex, reportTdIndex)
 		if err == nil {
-			categoriiCastig = append(categoriiCastig, models.WinCategory{
-				Id:     strings.TrimSpace(tds.Eq(0).Text()),
-				Amount: valoare,
-			})
+			categoriiCastig = append(categoriiCastig, categorie)
 		}
 	})
 
 	return categoriiCastig
 }
 
+func getHeaderColumnIndex(table *goquery.Selection, headerText string) int {
+	headerColumnIndex := -1
+
+	table.Find("thead tr th").EachWithBreak(func(i int, th *goquery.Selection) bool {
+		if strings.Contains(strings.ToLower(strings.TrimSpace(th.Text())), headerText) {
+			headerColumnIndex = i
+			return false
+		}
+
+		return true
+	})
+
+	return headerColumnIndex
+}
+
+// extractCategorieCastig reads one prize table row: the winners count sits right before the
+// prize value and the report (rollover) right after it. Only the prize value is mandatory.
+func extractCategorieCastig(tds *goquery.Selection, castigatoriTdIndex int, valoareCastigTdIndex int, reportTdIndex int) (models.WinCategory, error) {
+	valoare, err := strToEnglishFloat(getCellNumberText(tds, valoareCastigTdIndex))
+	if err != nil {
+		return models.WinCategory{}, err
+	}
+
+	categorie := models.WinCategory{
+		Id:     strings.TrimSpace(tds.Eq(0).Text()),
+		Amount: valoare,
+	}
+
+	if castigatoriTdIndex > 0 {
+		if castigatori, err := strToEnglishFloat(getCellNumberText(tds, castigatoriTdIndex)); err == nil {
+			categorie.WinnersCount = int(castigatori)
+		}
+	}
+
+	if report, err := strToEnglishFloat(getCellNumberText(tds, reportTdIndex)); err == nil {
+		categorie.Report = report
+	}
+
+	return categorie, nil
+}
+
+func getCellNumberText(tds *goquery.Selection, index int) string {
+	valoareStr := strings.TrimSpace(tds.Eq(index).Text())
+	if valoareStr == "-" || valoareStr == "" {
+		valoareStr = "0"
+	}
+
+	return valoareStr
+}
+
+// getJackpot returns the pool of the first (highest) category: the rolled over amount when
+// nobody won it, otherwise the amount paid per winner.
+func getJackpot(categoriiCastig []models.WinCategory) float64 {
+	if len(categoriiCastig) == 0 {
+		return 0
+	}
+
+	if categoriiCastig[0].Report > 0 {
+		return categoriiCastig[0].Report
+	}
+
+	return categoriiCastig[0].Amount
+}
+
 func strToEnglishFloat(valoareStr string) (float64, error) {
 	englishFloatFormat := strings.ReplaceAll(valoareStr, ".", "")
 	englishFloatFormat = strings.ReplaceAll(englishFloatFormat, ",", ".")
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"loto-suite/backend/models"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var update = flag.Bool("update", false, "write the golden files from the current parser output")
+
+// parserGolden is what a saved results page is expected to parse into.
+type parserGolden struct {
+	DrawResults   []models.DrawResult `json:"extrageri"`
+	SkippedBlocks int                 `json:"blocuri_ignorate"`
+}
+
+// TestParseDrawResultsDocument runs every saved results page testdata/parser/<game id>/<layout>.html
+// through the parser and compares the draws to <layout>.golden.json next to it. The quality checks
+// of the scraper are left out, so a layout the parser gets wrong shows up in the diff.
+// Run with -update to write the golden files, then review them before committing.
+func TestParseDrawResultsDocument(t *testing.T) {
+	pages, err := filepath.Glob(filepath.Join("testdata", "parser", "*", "*.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pages) == 0 {
+		t.Fatal("no saved results pages in testdata/parser")
+	}
+
+	for _, page := range pages {
+		gameId := filepath.Base(filepath.Dir(page))
+		layout := strings.TrimSuffix(filepath.Base(page), ".html")
+
+		t.Run(gameId+"/"+layout, func(t *testing.T) {
+			actual := parseSavedResultsPage(t, gameId, page)
+			goldenPath := strings.TrimSuffix(page, ".html") + ".golden.json"
+
+			if *update {
+				if err := os.WriteFile(goldenPath, actual, 0644); err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			expected, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("%v, run with -update to create it", err)
+			}
+
+			if bytes.Equal(expected, actual) {
+				return
+			}
+
+			expectedLines := strings.Split(string(expected), "\n")
+			actualLines := strings.Split(string(actual), "\n")
+			for i := 0; i < max(len(expectedLines), len(actualLines)); i++ {
+				expectedLine, actualLine := "", ""
+				if i < len(expectedLines) {
+					expectedLine = expectedLines[i]
+				}
+
+				if i < len(actualLines) {
+					actualLine = actualLines[i]
+				}
+
+				if expectedLine != actualLine {
+					t.Fatalf("%s differs from the golden file at line %d:\nexpected %s\ngot      %s",
+						page, i+1, strings.TrimSpace(expectedLine), strings.TrimSpace(actualLine))
+				}
+			}
+		})
+	}
+}
+
+func parseSavedResultsPage(t *testing.T, gameId string, page string) []byte {
+	t.Helper()
+
+	game, err := GetGameById(gameId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+
+	doc, err := goquery.NewDocumentFromReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	drawResults, skippedBlocks := parseDrawResultsDocument(game, doc)
+
+	data, err := json.MarshalIndent(parserGolden{
+		DrawResults:   drawResults,
+		SkippedBlocks: skippedBlocks,
+	}, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return append(data, '\n')
+}
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"loto-suite/backend/models"
+)
+
+// SystematicExpander lets a GameChecker decide how a systematic variant is split
+// into simple lines. Checkers that do not implement it get expandSystematicVariant.
+type SystematicExpander interface {
+	ExpandSystematic(varianta models.Variant, game *models.Game) []models.Variant
+}
+
+func isSystematicVariant(varianta models.Variant, game *models.Game) bool {
+	return varianta.IsSystematic && len(varianta.Numbers) > game.VariantMinNumbersCount
+}
+
+func expandVariant(checker GameChecker, varianta models.Variant, game *models.Game) []models.Variant {
+	if expander, ok := checker.(SystematicExpander); ok {
+		return expander.ExpandSystematic(varianta, game)
+	}
+
+	return expandSystematicVariant(varianta, game.VariantMinNumbersCount)
+}
+
+// expandSystematicVariant builds every k-number combination of the main numbers of varianta.
+// The secondary numbers (e.g. the joker) are played on each of them.
+func expandSystematicVariant(varianta models.Variant, k int) []models.Variant {
+	combinatii := []models.Variant{}
+
+	for _, indexes := range Combinations(len(varianta.Numbers), k) {
+		numere := make([]models.Number, 0, k)
+		for _, index := range indexes {
+			numere = append(numere, models.Number{Value: varianta.Numbers[index].Value})
+		}
+
+		numereSecundare := make([]models.Number, 0, len(varianta.SecondaryNumbers))
+		for _, numar := range varianta.SecondaryNumbers {
+			numereSecundare = append(numereSecundare, models.Number{Value: numar.Value})
+		}
+
+		combinatii = append(combinatii, models.Variant{
+			Id:               len(combinatii) + 1,
+			Numbers:          numere,
+			SecondaryNumbers: numereSecundare,
+		})
+	}
+
+	return combinatii
+}
+
+// Combinations returns every k-element index combination of n elements, in lexicographic order.
+func Combinations(n int, k int) [][]int {
+	result := [][]int{}
+	if k <= 0 || k > n {
+		return result
+	}
+
+	indexes := make([]int, k)
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	for {
+		result = append(result, append([]int{}, indexes...))
+
+		i := k - 1
+		for i >= 0 && indexes[i] == n-k+i {
+			i--
+		}
+
+		if i < 0 {
+			return result
+		}
+
+		indexes[i]++
+		for j := i + 1; j < k; j++ {
+			indexes[j] = indexes[j-1] + 1
+		}
+	}
+}
+
+func CombinationsCount(n int, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+
+	count := 1
+	for i := 1; i <= k; i++ {
+		count = count * (n - k + i) / i
+	}
+
+	return count
+}
+
+func checkSystematicVariant(checker GameChecker, game *models.Game, varianta *models.Variant, drawResult *models.DrawResult) {
+	varianta.Combinations = expandVariant(checker, *varianta, game)
+	varianta.WinsRegular = []models.Win{}
+	varianta.WinsSpecial = []models.Win{}
+
+	for i := range varianta.Combinations {
+		combinatie := &varianta.Combinations[i]
+
+		checker.CheckVariant(combinatie, drawResult.VariantRegular, game)
+		checker.CheckVariant(combinatie, drawResult.VariantSpecial, game)
+
+		varianta.WinsRegular = append(varianta.WinsRegular, combinatie.WinsRegular...)
+		varianta.WinsSpecial = append(varianta.WinsSpecial, combinatie.WinsSpecial...)
+
+		markCombinationWinners(varianta.Numbers, combinatie.Numbers)
+		markCombinationWinners(varianta.SecondaryNumbers, combinatie.SecondaryNumbers)
+	}
+}
+
+func markCombinationWinners(numere []models.Number, numereCombinatie []models.Number) {
+	for _, numar := range numereCombinatie {
+		if !numar.IsWinner {
+			continue
+		}
+
+		for j := range numere {
+			if numere[j].Value == numar.Value {
+				numere[j].IsWinner = true
+			}
+		}
+	}
+}
+
+func cumulateSystematicVariantWins(varianta *models.Variant, drawResult *models.DrawResult) {
+	for i := range varianta.Combinations {
+		cumulateVariantWins(&varianta.Combinations[i], drawResult)
+	}
+
+	cumulateVariantWins(varianta, drawResult)
+}
+
+func cumulateVariantWins(varianta *models.Variant, drawResult *models.DrawResult) {
+	varianta.WinsCumulatedRegular = cumulateWins([]models.WinCumulated{}, varianta.WinsRegular, drawResult.WinCategoriesVariantRegular)
+	varianta.WinsCumulatedSpecial = cumulateWins([]models.WinCumulated{}, varianta.WinsSpecial, drawResult.WinCategoriesVariantSpecial)
+	varianta.WinsTotal = sumWins(varianta.WinsCumulatedRegular) + sumWins(varianta.WinsCumulatedSpecial)
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"loto-suite/backend/models"
+	"reflect"
+	"testing"
+)
+
+func TestCombinations(t *testing.T) {
+	tests := []struct {
+		n, k     int
+		expected [][]int
+	}{
+		{4, 2, [][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}}},
+		{3, 3, [][]int{{0, 1, 2}}},
+		{3, 1, [][]int{{0}, {1}, {2}}},
+		{3, 0, [][]int{}},
+		{2, 3, [][]int{}},
+	}
+
+	for _, tt := range tests {
+		if combinations := Combinations(tt.n, tt.k); !reflect.DeepEqual(combinations, tt.expected) {
+			t.Errorf("Combinations(%d, %d): expected %v, got %v", tt.n, tt.k, tt.expected, combinations)
+		}
+	}
+}
+
+func TestCombinationsCount(t *testing.T) {
+	tests := []struct {
+		n, k     int
+		expected int
+	}{
+		{49, 6, 13983816},
+		{45, 5, 1221759},
+		{12, 6, 924},
+		{7, 6, 7},
+		{6, 6, 1},
+		{6, 0, 1},
+		{5, 6, 0},
+		{5, -1, 0},
+	}
+
+	for _, tt := range tests {
+		if count := CombinationsCount(tt.n, tt.k); count != tt.expected {
+			t.Errorf("CombinationsCount(%d, %d): expected %d, got %d", tt.n, tt.k, tt.expected, count)
+		}
+	}
+}
+
+func TestExpandSystematicVariant(t *testing.T) {
+	tests := []struct {
+		name     string
+		variant  models.Variant
+		k        int
+		expected []models.Variant
+	}{
+		{
+			name:    "6 numbers played by 5",
+			variant: models.Variant{Numbers: []models.Number{{Value: 1}, {Value: 2}, {Value: 3}, {Value: 4}, {Value: 5}, {Value: 6}}},
+			k:       5,
+			expected: []models.Variant{
+				{Id: 1, Numbers: []models.Number{{Value: 1}, {Value: 2}, {Value: 3}, {Value: 4}, {Value: 5}}, SecondaryNumbers: []models.Number{}},
+				{Id: 2, Numbers: []models.Number{{Value: 1}, {Value: 2}, {Value: 3}, {Value: 4}, {Value: 6}}, SecondaryNumbers: []models.Number{}},
+				{Id: 3, Numbers: []models.Number{{Value: 1}, {Value: 2}, {Value: 3}, {Value: 5}, {Value: 6}}, SecondaryNumbers: []models.Number{}},
+				{Id: 4, Numbers: []models.Number{{Value: 1}, {Value: 2}, {Value: 4}, {Value: 5}, {Value: 6}}, SecondaryNumbers: []models.Number{}},
+				{Id: 5, Numbers: []models.Number{{Value: 1}, {Value: 3}, {Value: 4}, {Value: 5}, {Value: 6}}, SecondaryNumbers: []models.Number{}},
+				{Id: 6, Numbers: []models.Number{{Value: 2}, {Value: 3}, {Value: 4}, {Value: 5}, {Value: 6}}, SecondaryNumbers: []models.Number{}},
+			},
+		},
+		{
+			name: "secondary numbers played on every combination",
+			variant: models.Variant{
+				Numbers:          []models.Number{{Value: 10, IsWinner: true}, {Value: 20}, {Value: 30}},
+				SecondaryNumbers: []models.Number{{Value: 7, IsWinner: true}},
+			},
+			k: 2,
+			expected: []models.Variant{
+				{Id: 1, Numbers: []models.Number{{Value: 10}, {Value: 20}}, SecondaryNumbers: []models.Number{{Value: 7}}},
+				{Id: 2, Numbers: []models.Number{{Value: 10}, {Value: 30}}, SecondaryNumbers: []models.Number{{Value: 7}}},
+				{Id: 3, Numbers: []models.Number{{Value: 20}, {Value: 30}}, SecondaryNumbers: []models.Number{{Value: 7}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if combinations := expandSystematicVariant(tt.variant, tt.k); !reflect.DeepEqual(combinations, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, combinations)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"loto-suite/backend/generics"
+	"loto-suite/backend/models"
+	"math"
+	"time"
+)
+
+// GetTaxBracketTable returns the tax table in force at date, or nil before the first one.
+func GetTaxBracketTable(date time.Time) *models.TaxBracketTable {
+	var inForce *models.TaxBracketTable
+
+	for _, table := range models.TaxBracketTables {
+		effectiveFrom, err := generics.TryParseDate(table.EffectiveFrom)
+		if err != nil || date.Before(effectiveFrom) {
+			continue
+		}
+
+		if inForce == nil || table.EffectiveFrom > inForce.EffectiveFrom {
+			inForce = table
+		}
+	}
+
+	return inForce
+}
+
+// CalculateTax applies the progressive brackets of table to a single prize, rounded to bani.
+func CalculateTax(amount float64, table *models.TaxBracketTable) float64 {
+	if table == nil || amount <= 0 {
+		return 0
+	}
+
+	tax := 0.0
+	lowerLimit := 0.0
+
+	for _, bracket := range table.Brackets {
+		upperLimit := bracket.UpTo
+		if upperLimit == 0 || upperLimit > amount {
+			upperLimit = amount
+		}
+
+		if upperLimit > lowerLimit {
+			tax += (upperLimit - lowerLimit) * bracket.Rate
+		}
+
+		if bracket.UpTo == 0 || bracket.UpTo >= amount {
+			break
+		}
+
+		lowerLimit = bracket.UpTo
+	}
+
+	return math.Round(tax*100) / 100
+}
+
+// applyTaxes sets the tax and net amount of every win in checkResult, using the rules
+// in force on the draw date, and the ticket totals.
+func applyTaxes(checkResult *models.CheckResult) {
+	drawDate, _ := generics.TryParseDate(checkResult.DrawResult.GameDate)
+	table := GetTaxBracketTable(drawDate)
+
+	for i := range checkResult.VarianteJucate {
+		varianta := &checkResult.VarianteJucate[i]
+		for j := range varianta.Combinations {
+			applyTax(varianta.Combinations[j].WinsCumulatedRegular, table)
+			applyTax(varianta.Combinations[j].WinsCumulatedSpecial, table)
+		}
+
+		applyTax(varianta.WinsCumulatedRegular, table)
+		applyTax(varianta.WinsCumulatedSpecial, table)
+	}
+
+	checkResult.TaxTotal = applyTax(checkResult.WinsCumulatedVariantRegular, table) +
+		applyTax(checkResult.WinsCumulatedVariantSpecial, table) +
+		applyTax(checkResult.WinsCumulatedLuckyNumber, table)
+
+	checkResult.WinsTotalNet = checkResult.WinsTotal - checkResult.TaxTotal
+}
+
+func applyTax(castiguriCumulate []models.WinCumulated, table *models.TaxBracketTable) float64 {
+	taxTotal := 0.0
+
+	for i := range castiguriCumulate {
+		castig := &castiguriCumulate[i]
+		castig.Tax = CalculateTax(castig.Amount, table)
+		castig.NetAmount = castig.Amount - castig.Tax
+
+		taxTotal += float64(castig.WinCount) * castig.Tax
+	}
+
+	return taxTotal
+}
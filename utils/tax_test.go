@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"loto-suite/backend/generics"
+	"loto-suite/backend/models"
+	"testing"
+)
+
+func TestGetTaxBracketTable(t *testing.T) {
+	tests := []struct {
+		date          string
+		effectiveFrom string
+	}{
+		{"2015-12-31", ""},
+		{"2016-01-01", "2016-01-01"},
+		{"2022-12-31", "2016-01-01"},
+		{"2023-01-01", "2023-01-01"},
+		{"2026-10-18", "2023-01-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			date, err := generics.TryParseDate(tt.date)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			effectiveFrom := ""
+			if table := GetTaxBracketTable(date); table != nil {
+				effectiveFrom = table.EffectiveFrom
+			}
+
+			if effectiveFrom != tt.effectiveFrom {
+				t.Errorf("expected the table in force from %q, got %q", tt.effectiveFrom, effectiveFrom)
+			}
+		})
+	}
+}
+
+func TestCalculateTax(t *testing.T) {
+	table2016 := getTestTaxBracketTable(t, "2016-01-01")
+	table2023 := getTestTaxBracketTable(t, "2023-01-01")
+
+	tests := []struct {
+		name     string
+		amount   float64
+		table    *models.TaxBracketTable
+		expected float64
+	}{
+		{"2023 first bracket", 5000, table2023, 150},
+		{"2023 first bracket limit", 10000, table2023, 300},
+		{"2023 second bracket", 20000, table2023, 2300},
+		{"2023 second bracket limit", 66750, table2023, 11650},
+		{"2023 third bracket", 100000, table2023, 24950},
+		{"2023 rounded to bani", 10000.37, table2023, 300.07},
+		{"2016 first bracket", 50000, table2016, 500},
+		{"2016 second bracket", 100000, table2016, 5987.5},
+		{"2016 third bracket", 500000, table2016, 74937.5},
+		{"no table", 100000, nil, 0},
+		{"no amount", 0, table2023, 0},
+		{"negative amount", -100, table2023, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tax := CalculateTax(tt.amount, tt.table); tax != tt.expected {
+				t.Errorf("expected tax %v on %v, got %v", tt.expected, tt.amount, tax)
+			}
+		})
+	}
+}
+
+func getTestTaxBracketTable(t *testing.T, effectiveFrom string) *models.TaxBracketTable {
+	t.Helper()
+
+	for _, table := range models.TaxBracketTables {
+		if table.EffectiveFrom == effectiveFrom {
+			return table
+		}
+	}
+
+	t.Fatalf("no tax table in force from %s", effectiveFrom)
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"loto-suite/backend/logging"
+	"loto-suite/backend/models"
+	"math/rand/v2"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("upstream circuit open")
+
+// upstreamConfig controls how requests to loto.ro are retried and when a host is given a rest.
+// Every value can be overridden through the environment.
+type upstreamConfig struct {
+	retries          int           // UPSTREAM_RETRIES: attempts after the first one
+	backoffBase      time.Duration // UPSTREAM_BACKOFF_BASE: wait before the first retry, doubled for each next one
+	backoffMax       time.Duration // UPSTREAM_BACKOFF_MAX
+	breakerThreshold int           // UPSTREAM_BREAKER_THRESHOLD: consecutive failed requests that open the circuit
+	breakerCooldown  time.Duration // UPSTREAM_BREAKER_COOLDOWN: how long an open circuit rejects requests
+	timeout          time.Duration // UPSTREAM_TIMEOUT: deadline of a request with all its retries
+}
+
+var upstream = upstreamConfig{
+	retries:          getEnvInt("UPSTREAM_RETRIES", 2),
+	backoffBase:      GetEnvDuration("UPSTREAM_BACKOFF_BASE", 500*time.Millisecond),
+	backoffMax:       GetEnvDuration("UPSTREAM_BACKOFF_MAX", 8*time.Second),
+	breakerThreshold: getEnvInt("UPSTREAM_BREAKER_THRESHOLD", 5),
+	breakerCooldown:  GetEnvDuration("UPSTREAM_BREAKER_COOLDOWN", time.Minute),
+	timeout:          GetEnvDuration("UPSTREAM_TIMEOUT", 20*time.Second),
+}
+
+// getTimeout returns how long a request may take with all its retries. The retries share the
+// deadline, so a slow host fails the caller within it instead of after every attempt timed out.
+func (c upstreamConfig) getTimeout() time.Duration {
+	return c.timeout
+}
+
+// getBackoff returns a random wait of up to backoffBase * 2^retry ("full jitter"), so clients
+// failing together do not retry together.
+func (c upstreamConfig) getBackoff(retry int) time.Duration {
+	backoff := c.backoffMax
+	if retry < 30 {
+		backoff = min(c.backoffBase<<retry, c.backoffMax)
+	}
+
+	if backoff <= 0 {
+		return 0
+	}
+
+	return rand.N(backoff) + 1
+}
+
+type circuitBreaker struct {
+	host                string
+	state               string
+	consecutiveFailures int
+	openedAt            time.Time
+	lastError           string
+	trialInFlight       bool
+}
+
+var (
+	circuitBreakers      = map[string]*circuitBreaker{}
+	circuitBreakersMutex sync.Mutex
+)
+
+// allow reports whether a request to the host may be sent. Once the cooldown of an open circuit
+// elapses, a single trial request is let through (half open) to probe the host.
+func (b *circuitBreaker) allow(now time.Time) bool {
+	switch b.state {
+	case models.CircuitOpen:
+		if now.Before(b.openedAt.Add(upstream.breakerCooldown)) {
+			return false
+		}
+
+		b.state = models.CircuitHalfOpen
+		b.trialInFlight = true
+		logging.Info("upstream", fmt.Sprintf("circuit for %s is half open, sending a trial request", b.host))
+		return true
+	case models.CircuitHalfOpen:
+		if b.trialInFlight {
+			return false
+		}
+
+		b.trialInFlight = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	if b.state != models.CircuitClosed {
+		logging.Info("upstream", fmt.Sprintf("circuit for %s closed", b.host))
+	}
+
+	b.state = models.CircuitClosed
+	b.consecutiveFailures = 0
+	b.trialInFlight = false
+	b.lastError = ""
+}
+
+// recordClientError ends a request the host answered with a 4xx status: the host is up, but the
+// request was rejected, so it neither closes the circuit nor clears the failures counted so far.
+func (b *circuitBreaker) recordClientError() {
+	b.trialInFlight = false
+}
+
+func (b *circuitBreaker) recordFailure(err error, now time.Time) {
+	b.consecutiveFailures++
+	b.trialInFlight = false
+	b.lastError = err.Error()
+
+	if b.state == models.CircuitHalfOpen || b.consecutiveFailures >= upstream.breakerThreshold {
+		if b.state != models.CircuitOpen {
+			logging.Error("upstream", fmt.Errorf("circuit for %s opened for %s after %d consecutive failures: %w",
+				b.host, upstream.breakerCooldown, b.consecutiveFailures, err), "")
+		}
+
+		b.state = models.CircuitOpen
+		b.openedAt = now
+	}
+}
+
+func getCircuitBreaker(host string) *circuitBreaker {
+	breaker, found := circuitBreakers[host]
+	if !found {
+		breaker = &circuitBreaker{host: host, state: models.CircuitClosed}
+		circuitBreakers[host] = breaker
+	}
+
+	return breaker
+}
+
+// doUpstreamRequest sends an idempotent request to an upstream host through its circuit breaker,
+// retrying network errors and 429/5xx responses with a jittered exponential backoff.
+func doUpstreamRequest(ctx context.Context, method string, url string, customHeaders map[string]string, body neturl.Values) (*http.Response, error) {
+	parsedUrl, err := neturl.Parse(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upstream url: %w", err)
+	}
+
+	host := parsedUrl.Host
+
+	circuitBreakersMutex.Lock()
+	allowed := getCircuitBreaker(host).allow(time.Now())
+	circuitBreakersMutex.Unlock()
+
+	if !allowed {
+		return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, host)
+	}
+
+	resp, err := doHttpRequestWithRetries(ctx, method, url, customHeaders, body)
+
+	circuitBreakersMutex.Lock()
+	if err != nil {
+		getCircuitBreaker(host).recordFailure(err, time.Now())
+	} else if resp.StatusCode >= http.StatusBadRequest {
+		getCircuitBreaker(host).recordClientError()
+	} else {
+		getCircuitBreaker(host).recordSuccess()
+	}
+	circuitBreakersMutex.Unlock()
+
+	return resp, err
+}
+
+func doHttpRequestWithRetries(ctx context.Context, method string, url string, customHeaders map[string]string, body neturl.Values) (*http.Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= upstream.retries; attempt++ {
+		if attempt > 0 {
+			backoff := upstream.getBackoff(attempt - 1)
+			logging.Info("upstream", fmt.Sprintf("retrying %s %s in %s (attempt %d of %d): %v", method, url, backoff, attempt+1, upstream.retries+1, lastErr))
+
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("%w (last error: %v)", ctx.Err(), lastErr)
+			case <-time.After(backoff):
+			}
+		}
+
+		resp, err := doHttpRequest(ctx, method, url, customHeaders, body)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+			resp.Body.Close()
+			lastErr = fmt.Errorf("failed to %s: upstream returned %s", method, resp.Status)
+			continue
+		}
+
+		return resp, nil
+	}
+
+	return nil, lastErr
+}
+
+// GetCircuitBreakerStates returns the state of the circuit breaker of every upstream host contacted so far.
+func GetCircuitBreakerStates() []models.CircuitBreakerState {
+	circuitBreakersMutex.Lock()
+	defer circuitBreakersMutex.Unlock()
+
+	states := []models.CircuitBreakerState{}
+	for _, breaker := range circuitBreakers {
+		state := models.CircuitBreakerState{
+			Host:                breaker.host,
+			State:               breaker.state,
+			ConsecutiveFailures: breaker.consecutiveFailures,
+			LastError:           breaker.lastError,
+		}
+
+		if breaker.state != models.CircuitClosed {
+			openedAt := breaker.openedAt
+			retryAt := breaker.openedAt.Add(upstream.breakerCooldown)
+			state.OpenedAt = &openedAt
+			state.RetryAt = &retryAt
+		}
+
+		states = append(states, state)
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Host < states[j].Host
+	})
+
+	return states
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil && value >= 0 {
+		return value
+	}
+
+	return defaultValue
+}
+
+// GetEnvDuration reads a positive duration such as "10m" from the environment.
+func GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+
+	return defaultValue
+}
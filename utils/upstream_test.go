@@ -0,0 +1,194 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"loto-suite/backend/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// useUpstreamConfig replaces the upstream settings and the circuit breakers for the rest of the test.
+func useUpstreamConfig(t *testing.T, config upstreamConfig) {
+	t.Helper()
+
+	previous := upstream
+	upstream = config
+
+	circuitBreakersMutex.Lock()
+	previousBreakers := circuitBreakers
+	circuitBreakers = map[string]*circuitBreaker{}
+	circuitBreakersMutex.Unlock()
+
+	t.Cleanup(func() {
+		upstream = previous
+
+		circuitBreakersMutex.Lock()
+		circuitBreakers = previousBreakers
+		circuitBreakersMutex.Unlock()
+	})
+}
+
+func TestGetBackoff(t *testing.T) {
+	config := upstreamConfig{backoffBase: 100 * time.Millisecond, backoffMax: time.Second}
+
+	tests := []struct {
+		retry    int
+		expected time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{1, 200 * time.Millisecond},
+		{3, 800 * time.Millisecond},
+		{4, time.Second},
+		{10, time.Second},
+		{64, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.retry), func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if backoff := config.getBackoff(tt.retry); backoff <= 0 || backoff > tt.expected {
+					t.Fatalf("expected a backoff in (0, %s], got %s", tt.expected, backoff)
+				}
+			}
+		})
+	}
+
+	if backoff := (upstreamConfig{}).getBackoff(3); backoff != 0 {
+		t.Errorf("expected no backoff without a base, got %s", backoff)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	useUpstreamConfig(t, upstreamConfig{breakerThreshold: 2, breakerCooldown: time.Minute})
+
+	start := time.Now()
+	breaker := &circuitBreaker{host: "www.loto.ro", state: models.CircuitClosed}
+
+	steps := []struct {
+		action   string
+		offset   time.Duration
+		allowed  bool
+		state    string
+		failures int
+	}{
+		{"allow", 0, true, models.CircuitClosed, 0},
+		{"failure", 0, false, models.CircuitClosed, 1},
+		{"client error", 0, false, models.CircuitClosed, 1},
+		{"failure", 0, false, models.CircuitOpen, 2},
+		{"allow", 30 * time.Second, false, models.CircuitOpen, 2},
+		{"allow", time.Minute, true, models.CircuitHalfOpen, 2},
+		{"allow", time.Minute, false, models.CircuitHalfOpen, 2},
+		{"failure", time.Minute, false, models.CircuitOpen, 3},
+		{"allow", 2 * time.Minute, true, models.CircuitHalfOpen, 3},
+		{"client error", 2 * time.Minute, false, models.CircuitHalfOpen, 3},
+		{"allow", 2 * time.Minute, true, models.CircuitHalfOpen, 3},
+		{"success", 2 * time.Minute, false, models.CircuitClosed, 0},
+	}
+
+	for i, step := range steps {
+		now := start.Add(step.offset)
+
+		switch step.action {
+		case "allow":
+			if allowed := breaker.allow(now); allowed != step.allowed {
+				t.Fatalf("step %d: expected allowed %v, got %v", i, step.allowed, allowed)
+			}
+		case "success":
+			breaker.recordSuccess()
+		case "client error":
+			breaker.recordClientError()
+		case "failure":
+			breaker.recordFailure(errors.New("connection refused"), now)
+		}
+
+		if breaker.state != step.state || breaker.consecutiveFailures != step.failures {
+			t.Fatalf("step %d (%s): expected %s with %d failures, got %s with %d",
+				i, step.action, step.state, step.failures, breaker.state, breaker.consecutiveFailures)
+		}
+	}
+}
+
+func TestDoUpstreamRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		err      bool
+		requests int32
+		failures int
+	}{
+		{"success", []int{http.StatusOK}, false, 1, 0},
+		{"retried server error", []int{http.StatusBadGateway, http.StatusOK}, false, 2, 0},
+		{"retried rate limit", []int{http.StatusTooManyRequests, http.StatusOK}, false, 2, 0},
+		{"server errors", []int{http.StatusBadGateway, http.StatusServiceUnavailable}, true, 2, 2},
+		{"client error is not retried", []int{http.StatusNotFound}, false, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(requests.Add(1)) - 1
+				w.WriteHeader(tt.statuses[min(n, len(tt.statuses)-1)])
+			}))
+			defer server.Close()
+
+			useUpstreamConfig(t, upstreamConfig{retries: 1, backoffBase: time.Millisecond, backoffMax: time.Millisecond, breakerThreshold: 5, breakerCooldown: time.Minute, timeout: 5 * time.Second})
+
+			// One failure is already counted, only a success clears it
+			circuitBreakersMutex.Lock()
+			circuitBreakers[strings.TrimPrefix(server.URL, "http://")] = &circuitBreaker{
+				host:                strings.TrimPrefix(server.URL, "http://"),
+				state:               models.CircuitClosed,
+				consecutiveFailures: 1,
+			}
+			circuitBreakersMutex.Unlock()
+
+			resp, err := doUpstreamRequest(context.Background(), "GET", server.URL, nil, nil)
+			if (err != nil) != tt.err {
+				t.Fatalf("expected an error %v, got %v", tt.err, err)
+			}
+
+			if resp != nil {
+				resp.Body.Close()
+			}
+
+			if requests.Load() != tt.requests {
+				t.Errorf("expected %d requests, got %d", tt.requests, requests.Load())
+			}
+
+			if states := GetCircuitBreakerStates(); len(states) != 1 || states[0].ConsecutiveFailures != tt.failures {
+				t.Errorf("expected %d consecutive failures, got %+v", tt.failures, states)
+			}
+		})
+	}
+}
+
+func TestDoUpstreamRequestDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	useUpstreamConfig(t, upstreamConfig{retries: 3, backoffBase: time.Millisecond, backoffMax: time.Millisecond, breakerThreshold: 5, breakerCooldown: time.Minute, timeout: 100 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), upstream.getTimeout())
+	defer cancel()
+
+	start := time.Now()
+	if _, err := doUpstreamRequest(ctx, "GET", server.URL, nil, nil); err == nil {
+		t.Fatal("expected the request to fail at the deadline")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the retries to share the deadline of %s, took %s", upstream.getTimeout(), elapsed)
+	}
+}
@@ -75,7 +75,7 @@ func GetDrawDates(daysBack int) []models.DrawDate {
 	loc := now.Location()
 
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
-	drawTime := time.Date(now.Year(), now.Month(), now.Day(), 21, 00, 0, 0, loc)
+	drawTime := time.Date(now.Year(), now.Month(), now.Day(), drawHour, 0, 0, 0, loc)
 
 	maxBackDate := today.AddDate(0, 0, -daysBack)
 
@@ -106,6 +106,31 @@ func GetDrawDates(daysBack int) []models.DrawDate {
 	return dates
 }
 
+// GetNextDrawDates returns the first count draw dates on or after from.
+func GetNextDrawDates(from time.Time, count int) []time.Time {
+	dates := make([]time.Time, 0, count)
+
+	for d := from; len(dates) < count; d = d.AddDate(0, 0, 1) {
+		if _, isDrawDay := generics.DrawDays[int(d.Weekday())]; isDrawDay {
+			dates = append(dates, d)
+		}
+	}
+
+	return dates
+}
+
+// countDrawDates returns the number of draw days between from and to (inclusive).
+func countDrawDates(from time.Time, to time.Time) int {
+	count := 0
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if _, isDrawDay := generics.DrawDays[int(d.Weekday())]; isDrawDay {
+			count++
+		}
+	}
+
+	return count
+}
+
 func GetGameById(gameId string) (*models.Game, error) {
 	for _, game := range models.Games {
 		if game.Id == gameId {
@@ -113,5 +138,10 @@ func GetGameById(gameId string) (*models.Game, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("unsupported game: %s (use 649, 540, or joker)", gameId)
+	gameIds := []string{}
+	for _, game := range models.Games {
+		gameIds = append(gameIds, game.Id)
+	}
+
+	return nil, fmt.Errorf("%w: %s (use %s)", ErrUnsupportedGame, gameId, strings.Join(gameIds, ", "))
 }
@@ -0,0 +1,217 @@
+package utils
+
+import (
+	"fmt"
+	"loto-suite/backend/generics"
+	"loto-suite/backend/models"
+	"strings"
+)
+
+// TicketValidationError is returned by the ticket checks when the request breaks the game rules.
+type TicketValidationError struct {
+	Errors []models.ValidationError
+}
+
+func (e *TicketValidationError) Error() string {
+	messages := []string{}
+	for _, validationError := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%s: %s", validationError.Field, validationError.Message))
+	}
+
+	return fmt.Sprintf("invalid ticket: %s", strings.Join(messages, "; "))
+}
+
+// ValidateTicket checks a CheckRequest against the rules of its models.Game and reports
+// every problem found, per variant and per number, instead of stopping at the first one.
+func ValidateTicket(request models.CheckRequest) models.ValidationResult {
+	return validateTicket(request, true)
+}
+
+func validateTicket(request models.CheckRequest, requireDate bool) models.ValidationResult {
+	v := ticketValidator{errors: []models.ValidationError{}}
+	v.validate(request, requireDate)
+
+	return models.ValidationResult{
+		IsValid: len(v.errors) == 0,
+		Errors:  v.errors,
+	}
+}
+
+type ticketValidator struct {
+	errors []models.ValidationError
+}
+
+func (v *ticketValidator) add(field string, code string, message string, variantIndex *int, numberIndex *int) {
+	v.errors = append(v.errors, models.ValidationError{
+		Field:        field,
+		Code:         code,
+		Message:      message,
+		VariantIndex: variantIndex,
+		NumberIndex:  numberIndex,
+	})
+}
+
+func (v *ticketValidator) validate(request models.CheckRequest, requireDate bool) {
+	if strings.TrimSpace(request.Date) == "" {
+		if requireDate {
+			v.add("date", models.ValidationCodeDateRequired, "the draw date is required", nil, nil)
+		}
+	} else if _, err := generics.TryParseDate(request.Date); err != nil {
+		v.add("date", models.ValidationCodeInvalidDate, fmt.Sprintf("unsupported date format: %s", request.Date), nil, nil)
+	}
+
+	gameId := strings.ToLower(strings.TrimSpace(request.GameId))
+	if gameId == "" {
+		v.add("game_id", models.ValidationCodeGameRequired, "the game is required", nil, nil)
+		return
+	}
+
+	game, err := GetGameById(gameId)
+	if err != nil {
+		v.add("game_id", models.ValidationCodeUnsupportedGame, err.Error(), nil, nil)
+		return
+	}
+
+	if request.DrawCount < 0 || request.DrawCount > game.ConsecutiveDrawsMaxCount {
+		v.add("numar_extrageri", models.ValidationCodeDrawCountOutOfRange,
+			fmt.Sprintf("a ticket can be played for 1 to %d consecutive draws", game.ConsecutiveDrawsMaxCount), nil, nil)
+	}
+
+	v.validateDateTo(request, game)
+
+	if len(request.Variants) == 0 && strings.TrimSpace(request.LuckyNumber) == "" {
+		v.add("variante", models.ValidationCodeVariantsRequired,
+			fmt.Sprintf("at least one set of numbers or a %s number is required", game.LuckyNumberName), nil, nil)
+	}
+
+	if len(request.Variants) > game.VariantsMaxCount {
+		v.add("variante", models.ValidationCodeTooManyVariants,
+			fmt.Sprintf("at most %d variants can be played on a %s ticket", game.VariantsMaxCount, game.DisplayName), nil, nil)
+	}
+
+	for i, varianta := range normalizeVariants(request.Variants, game) {
+		v.validateVariant(i, varianta, game)
+	}
+
+	v.validateLuckyNumber(strings.TrimSpace(request.LuckyNumber), game)
+}
+
+// validateDateTo checks the last draw of a ticket played for a range of draws (date to date_to).
+func (v *ticketValidator) validateDateTo(request models.CheckRequest, game *models.Game) {
+	if strings.TrimSpace(request.DateTo) == "" {
+		return
+	}
+
+	dateTo, err := generics.TryParseDate(request.DateTo)
+	if err != nil {
+		v.add("date_to", models.ValidationCodeInvalidDate, fmt.Sprintf("unsupported date format: %s", request.DateTo), nil, nil)
+		return
+	}
+
+	date, err := generics.TryParseDate(request.Date)
+	if err != nil {
+		return
+	}
+
+	if dateTo.Before(date) {
+		v.add("date_to", models.ValidationCodeInvalidDate, "the last draw date is before the first one", nil, nil)
+		return
+	}
+
+	if request.DrawCount > 0 {
+		v.add("numar_extrageri", models.ValidationCodeDrawCountOutOfRange, "use either numar_extrageri or date_to", nil, nil)
+		return
+	}
+
+	if drawCount := countDrawDates(date, dateTo); drawCount == 0 || drawCount > game.ConsecutiveDrawsMaxCount {
+		v.add("date_to", models.ValidationCodeDrawCountOutOfRange,
+			fmt.Sprintf("a ticket can be played for 1 to %d consecutive draws, the range has %d", game.ConsecutiveDrawsMaxCount, drawCount), nil, nil)
+	}
+}
+
+func (v *ticketValidator) validateVariant(variantIndex int, varianta models.Variant, game *models.Game) {
+	field := fmt.Sprintf("variante[%d].numere", variantIndex)
+
+	minNumbers := game.VariantMinNumbersCount
+	maxNumbers := minNumbers
+	if varianta.IsSystematic {
+		maxNumbers = game.VariantMaxNumbersCount
+	}
+
+	if len(varianta.Numbers) < minNumbers {
+		v.add(field, models.ValidationCodeTooFewNumbers,
+			fmt.Sprintf("a variant needs %d numbers", minNumbers), &variantIndex, nil)
+	} else if len(varianta.Numbers) > maxNumbers {
+		message := fmt.Sprintf("a variant can have at most %d numbers", maxNumbers)
+		if !varianta.IsSystematic && maxNumbers < game.VariantMaxNumbersCount {
+			message += " unless it is played as a system"
+		}
+
+		v.add(field, models.ValidationCodeTooManyNumbers, message, &variantIndex, nil)
+	}
+
+	v.validateNumbers(field, variantIndex, varianta.Numbers, game.VariantMinNumber, game.VariantMaxNumber)
+	v.validateSecondaryNumbers(variantIndex, varianta.SecondaryNumbers, game)
+}
+
+// validateSecondaryNumbers checks the numbers played from the secondary pool of the game (e.g. the joker).
+// They come from their own range, so they may repeat one of the main numbers.
+func (v *ticketValidator) validateSecondaryNumbers(variantIndex int, numere []models.Number, game *models.Game) {
+	field := fmt.Sprintf("variante[%d].numere_secundare", variantIndex)
+
+	pool := game.SecondaryPool
+	if pool == nil {
+		if len(numere) > 0 {
+			v.add(field, models.ValidationCodeSecondaryNumbersCount,
+				fmt.Sprintf("%s has no secondary numbers", game.DisplayName), &variantIndex, nil)
+		}
+
+		return
+	}
+
+	if len(numere) != pool.Count {
+		v.add(field, models.ValidationCodeSecondaryNumbersCount,
+			fmt.Sprintf("a variant needs %d %s number(s)", pool.Count, pool.Name), &variantIndex, nil)
+	}
+
+	v.validateNumbers(field, variantIndex, numere, pool.MinNumber, pool.MaxNumber)
+}
+
+func (v *ticketValidator) validateNumbers(field string, variantIndex int, numere []models.Number, minNumber int, maxNumber int) {
+	seen := map[int]bool{}
+	for j, numar := range numere {
+		numberIndex := j
+		numberField := fmt.Sprintf("%s[%d]", field, j)
+
+		if numar.Value < minNumber || numar.Value > maxNumber {
+			v.add(numberField, models.ValidationCodeNumberOutOfRange,
+				fmt.Sprintf("%d is not between %d and %d", numar.Value, minNumber, maxNumber), &variantIndex, &numberIndex)
+		}
+
+		if seen[numar.Value] {
+			v.add(numberField, models.ValidationCodeDuplicateNumber,
+				fmt.Sprintf("%d is played more than once", numar.Value), &variantIndex, &numberIndex)
+		}
+
+		seen[numar.Value] = true
+	}
+}
+
+func (v *ticketValidator) validateLuckyNumber(noroc string, game *models.Game) {
+	if noroc == "" {
+		return
+	}
+
+	if len(noroc) != game.LuckyNumberDigitCount {
+		v.add("noroc", models.ValidationCodeLuckyNumberLength,
+			fmt.Sprintf("%s must have %d digits", game.LuckyNumberName, game.LuckyNumberDigitCount), nil, nil)
+	}
+
+	for _, c := range noroc {
+		if c < '0' || c > '9' {
+			v.add("noroc", models.ValidationCodeLuckyNumberNotNumeric,
+				fmt.Sprintf("%s must contain only digits", game.LuckyNumberName), nil, nil)
+			return
+		}
+	}
+}
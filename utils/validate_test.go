@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"fmt"
+	"loto-suite/backend/models"
+	"testing"
+)
+
+func TestValidateTicket(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  models.CheckRequest
+		expected []string
+	}{
+		{
+			name:     "valid ticket",
+			request:  newTestRequest("2024-03-28", 1, 2, 3, 4, 5, 6),
+			expected: []string{},
+		},
+		{
+			name:     "missing game and date",
+			request:  models.CheckRequest{},
+			expected: []string{"date:date_required", "game_id:game_required"},
+		},
+		{
+			name:     "unsupported game",
+			request:  models.CheckRequest{GameId: "keno", Date: "2024-03-28"},
+			expected: []string{"game_id:unsupported_game"},
+		},
+		{
+			name:     "invalid date",
+			request:  newTestRequest("28 march", 1, 2, 3, 4, 5, 6),
+			expected: []string{"date:invalid_date"},
+		},
+		{
+			name:     "nothing played",
+			request:  models.CheckRequest{GameId: "649", Date: "2024-03-28"},
+			expected: []string{"variante:variants_required"},
+		},
+		{
+			name: "too many variants",
+			request: models.CheckRequest{GameId: "649", Date: "2024-03-28", Variants: []models.Variant{
+				{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6)},
+				{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6)},
+				{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6)},
+				{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6)},
+			}},
+			expected: []string{"variante:too_many_variants"},
+		},
+		{
+			name:     "too few numbers",
+			request:  newTestRequest("2024-03-28", 1, 2, 3),
+			expected: []string{"variante[0].numere:too_few_numbers"},
+		},
+		{
+			name:     "too many numbers without a system",
+			request:  newTestRequest("2024-03-28", 1, 2, 3, 4, 5, 6, 7),
+			expected: []string{"variante[0].numere:too_many_numbers"},
+		},
+		{
+			name: "system",
+			request: models.CheckRequest{GameId: "649", Date: "2024-03-28", Variants: []models.Variant{
+				{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6, 7, 8), IsSystematic: true},
+			}},
+			expected: []string{},
+		},
+		{
+			name:     "number out of range and duplicate",
+			request:  newTestRequest("2024-03-28", 1, 2, 3, 3, 5, 50),
+			expected: []string{"variante[0].numere[3]:duplicate_number", "variante[0].numere[5]:number_out_of_range"},
+		},
+		{
+			name:     "lucky number",
+			request:  models.CheckRequest{GameId: "649", Date: "2024-03-28", LuckyNumber: "12a4"},
+			expected: []string{"noroc:lucky_number_invalid_length", "noroc:lucky_number_not_numeric"},
+		},
+		{
+			name:     "draw count out of range",
+			request:  models.CheckRequest{GameId: "649", Date: "2024-03-28", DrawCount: 11, LuckyNumber: "1234567"},
+			expected: []string{"numar_extrageri:draw_count_out_of_range"},
+		},
+		{
+			name:     "date range and draw count",
+			request:  models.CheckRequest{GameId: "649", Date: "2024-03-28", DateTo: "2024-04-04", DrawCount: 2, LuckyNumber: "1234567"},
+			expected: []string{"numar_extrageri:draw_count_out_of_range"},
+		},
+		{
+			name:     "date range backwards",
+			request:  models.CheckRequest{GameId: "649", Date: "2024-03-28", DateTo: "2024-03-01", LuckyNumber: "1234567"},
+			expected: []string{"date_to:invalid_date"},
+		},
+		{
+			name:     "date range too long",
+			request:  models.CheckRequest{GameId: "649", Date: "2024-01-04", DateTo: "2024-03-28", LuckyNumber: "1234567"},
+			expected: []string{"date_to:draw_count_out_of_range"},
+		},
+		{
+			name: "joker in the legacy format",
+			request: models.CheckRequest{GameId: "joker", Date: "2024-03-28", Variants: []models.Variant{
+				{Numbers: newTestNumbers(1, 2, 3, 4, 5, 20)},
+			}},
+			expected: []string{},
+		},
+		{
+			name: "joker out of its range",
+			request: models.CheckRequest{GameId: "joker", Date: "2024-03-28", Variants: []models.Variant{
+				{Numbers: newTestNumbers(1, 2, 3, 4, 5), SecondaryNumbers: newTestNumbers(21)},
+			}},
+			expected: []string{"variante[0].numere_secundare[0]:number_out_of_range"},
+		},
+		{
+			name: "joker missing",
+			request: models.CheckRequest{GameId: "joker", Date: "2024-03-28", Variants: []models.Variant{
+				{Numbers: newTestNumbers(1, 2, 3, 4, 5)},
+			}},
+			expected: []string{"variante[0].numere_secundare:invalid_secondary_numbers_count"},
+		},
+		{
+			name: "secondary numbers on a game without them",
+			request: models.CheckRequest{GameId: "649", Date: "2024-03-28", Variants: []models.Variant{
+				{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6), SecondaryNumbers: newTestNumbers(1)},
+			}},
+			expected: []string{"variante[0].numere_secundare:invalid_secondary_numbers_count"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateTicket(tt.request)
+
+			actual := []string{}
+			for _, validationError := range result.Errors {
+				actual = append(actual, validationError.Field+":"+validationError.Code)
+			}
+
+			if fmt.Sprint(actual) != fmt.Sprint(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+
+			if result.IsValid != (len(tt.expected) == 0) {
+				t.Errorf("expected is_valid %v, got %v", len(tt.expected) == 0, result.IsValid)
+			}
+		})
+	}
+}
+
+func TestValidateTicketIndexes(t *testing.T) {
+	request := models.CheckRequest{GameId: "649", Date: "2024-03-28", Variants: []models.Variant{
+		{Numbers: newTestNumbers(1, 2, 3, 4, 5, 6)},
+		{Numbers: newTestNumbers(1, 2, 3, 4, 5, 0)},
+	}}
+
+	result := ValidateTicket(request)
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected one error, got %v", result.Errors)
+	}
+
+	validationError := result.Errors[0]
+	if validationError.VariantIndex == nil || *validationError.VariantIndex != 1 {
+		t.Errorf("expected variant index 1, got %v", validationError.VariantIndex)
+	}
+
+	if validationError.NumberIndex == nil || *validationError.NumberIndex != 5 {
+		t.Errorf("expected number index 5, got %v", validationError.NumberIndex)
+	}
+}